@@ -4,9 +4,10 @@ import (
 	"go-todolist/entity"
 	"go-todolist/request"
 	"go-todolist/services"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/paginator"
 	"go-todolist/utils/responses"
 	"net/http"
-	"regexp"
 
 	"github.com/gin-gonic/gin"
 )
@@ -39,32 +40,24 @@ func NewCategoryController(categoryService services.CategoryService, categoryEnt
 // @Param	name			formData	string	true	"Category Name"									maxLength(100)
 // @Success 201 object responses.Response{errors=string,data=string} "Create Success"
 // @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 409 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Router	/category [post]
 func (h *categoryController) Create(c *gin.Context) {
 	var input request.CategoryCreateOrUpdateRequest
 	err := c.ShouldBind(&input)
 	if err != nil {
-		response := responses.ErrorsResponse(http.StatusBadRequest, "Failed to process request", err.Error(), nil)
-		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		c.Error(apperr.Wrap(apperr.Validation, err))
 		return
 	}
 
-	createCategory, createCategoryErr := h.categoryService.CreateCategory(input)
+	createCategory, createCategoryErr := h.categoryService.CreateCategory(input, GetAuthUserID(c))
 	if createCategoryErr != nil {
-		match, _ := regexp.MatchString("Duplicate", createCategoryErr.Error())
-		if match {
-			response := responses.ErrorsResponse(http.StatusInternalServerError, "Failed to process request", "Error 1062: Duplicate entry "+input.Name, nil)
-			c.AbortWithStatusJSON(http.StatusInternalServerError, response)
-			return
-		} else {
-			response := responses.ErrorsResponse(http.StatusInternalServerError, "Failed to process request", createCategoryErr.Error(), nil)
-			c.AbortWithStatusJSON(http.StatusInternalServerError, response)
-			return
-		}
+		c.Error(createCategoryErr)
+		return
 	}
 
-	response := responses.SuccessResponse(http.StatusCreated, "Create Success", createCategory)
+	response := responses.SuccessResponse(GetLocale(c), http.StatusCreated, "Create Success", createCategory)
 	c.JSON(http.StatusCreated, response)
 	return
 }
@@ -76,9 +69,13 @@ func (h *categoryController) Create(c *gin.Context) {
 // @Param	Authorization	header		string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
 // @Param	id				query		integer	false	"Category ID"									minimum(1)
 // @Param	name			query		string	false	"Category Name"									maxLength(100)
-// @Param	page			query		integer	true	"Page"											minimum(1) default(1)
+// @Param	sort			query		string	false	"Sort fields separated by commas, - for descending (id, name, created_at, updated_at)"	example(-created_at)
+// @Param	page			query		integer	false	"Page (without page, the cursor pagination is used)"	minimum(1)
 // @Param	limit			query		integer	true	"Limit"											minimum(2) default(5)
-// @Success 200 object responses.PageResponse{errors=string,data=string} "Record not found || Successfully get category"
+// @Param	cursor			query		string	false	"next_cursor or prev_cursor of the previous response, empty for the first page"
+// @Param	with_total		query		boolean	false	"Count the total with the cursor pagination"	default(false)
+// @Success 200 object responses.PageResponse{errors=string,data=[]model.Category} "Successfully get category list (page)"
+// @Success 200 object responses.CursorPageResponse{errors=string,data=[]model.Category} "Successfully get category list (cursor)"
 // @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Router	/category [get]
@@ -86,13 +83,30 @@ func (h *categoryController) GetByList(c *gin.Context) {
 	var input request.CategoryGetListRequest
 	err := c.ShouldBind(&input)
 	if err != nil {
-		response := responses.ErrorsResponse(http.StatusBadRequest, "Failed to process request", err.Error(), nil)
-		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		c.Error(apperr.Wrap(apperr.Validation, err))
+		return
+	}
+
+	sort, sortErr := paginator.Sort(input.Sort, entity.CategorySortColumns)
+	if sortErr != nil {
+		c.Error(apperr.Wrap(apperr.Validation, sortErr))
+		return
+	}
+
+	if input.IsCursor() {
+		category, categoryErr := h.categoryEntity.GetCategoryListByCursor(input.Id, GetAuthUserID(c), input.Name, sort, input.Cursor, input.Limit, input.WithTotal)
+		if categoryErr != nil {
+			c.Error(categoryErr)
+			return
+		}
+
+		response := responses.SuccessCursorPageResponse(GetLocale(c), http.StatusOK, "Successfully get category list", category.PageLimit, category.Total, category.NextCursor, category.PrevCursor, category.Data)
+		c.JSON(http.StatusOK, response)
 		return
 	}
 
-	category := h.categoryEntity.GetCategoryList(input.Id, input.Name, input.Page, input.Limit)
-	response := responses.SuccessPageResponse(http.StatusOK, "Successfully get category list", category.CurrentPage, category.PageLimit, category.Total, category.Pages, category.Data)
+	category := h.categoryEntity.GetCategoryList(input.Id, GetAuthUserID(c), input.Name, sort, input.Page, input.Limit)
+	response := responses.SuccessPageResponse(GetLocale(c), http.StatusOK, "Successfully get category list", category.CurrentPage, category.PageLimit, category.Total, category.Pages, category.Data)
 	c.JSON(http.StatusOK, response)
 	return
 }
@@ -103,32 +117,26 @@ func (h *categoryController) GetByList(c *gin.Context) {
 // @Produce	application/json
 // @Param	Authorization	header		string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
 // @Param	id				path		integer	true	"Category ID"									minimum(1)
-// @Success	200 object responses.Response{errors=string,data=string} "Record not found || Successfully get category"
+// @Success	200 object responses.Response{errors=string,data=string} "Successfully get category"
 // @Failure	400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure	404 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Failure	500 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Router	/category/{id} [get]
 func (h *categoryController) Get(c *gin.Context) {
 	var input request.CategoryGetRequest
 	err := c.ShouldBindUri(&input)
 	if err != nil {
-		response := responses.ErrorsResponseByCode(http.StatusBadRequest, "Failed to process request", responses.IdInvalid, nil)
-		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		c.Error(apperr.ErrIdInvalid)
 		return
 	}
 
-	category, categoryErr := h.categoryEntity.GetCategory(input.Id)
+	category, categoryErr := h.categoryEntity.GetCategory(input.Id, GetAuthUserID(c))
 	if categoryErr != nil {
-		response := responses.ErrorsResponse(http.StatusInternalServerError, "Failed to process request", categoryErr.Error(), nil)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
-		return
-	}
-	if category.ID == 0 {
-		response := responses.SuccessResponse(http.StatusOK, "Record not found", nil)
-		c.JSON(http.StatusOK, response)
+		c.Error(categoryErr)
 		return
 	}
 
-	response := responses.SuccessResponse(http.StatusOK, "Successfully get category", category)
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Successfully get category", category)
 	c.JSON(http.StatusOK, response)
 	return
 }
@@ -142,7 +150,9 @@ func (h *categoryController) Get(c *gin.Context) {
 // @Param	name			query		string	true	"Category Name"									maxLength(100)
 // @Success	200 object responses.Response{errors=string,data=string} "Update Success"
 // @Failure	400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure	403 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Failure	404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure	409 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Failure	500 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Router	/category/{id} [PATCH]
 func (h *categoryController) Update(c *gin.Context) {
@@ -150,43 +160,39 @@ func (h *categoryController) Update(c *gin.Context) {
 	var id request.CategoryGetRequest
 	err := c.ShouldBindUri(&id)
 	if err != nil {
-		response := responses.ErrorsResponseByCode(http.StatusBadRequest, "Failed to process request", responses.IdInvalid, nil)
-		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		c.Error(apperr.ErrIdInvalid)
 		return
 	}
 
-	category, categoryErr := h.categoryEntity.GetCategory(id.Id)
+	category, categoryErr := h.categoryEntity.GetCategory(id.Id, GetAuthUserID(c))
 	if categoryErr != nil {
-		response := responses.ErrorsResponse(http.StatusInternalServerError, "Failed to process request", categoryErr.Error(), nil)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		c.Error(categoryErr)
 		return
 	}
-	if category.ID == 0 {
-		response := responses.ErrorsResponseByCode(http.StatusNotFound, "Failed to process request", responses.RecordNotFound, nil)
-		c.AbortWithStatusJSON(http.StatusNotFound, response)
+	if category.IsSystem() {
+		c.Error(entity.ErrSystemCategoryReadOnly)
 		return
 	}
 
 	inputErr := c.ShouldBind(&input)
 	if inputErr != nil {
-		response := responses.ErrorsResponse(http.StatusBadRequest, "Failed to process request", inputErr.Error(), nil)
-		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		c.Error(apperr.Wrap(apperr.Validation, inputErr))
 		return
 	}
 
-	updateCategory, updateCategoryErr := h.categoryService.UpdateCategory(input, id.Id)
+	updateCategory, updateCategoryErr := h.categoryService.UpdateCategory(input, id.Id, GetAuthUserID(c))
 	if updateCategoryErr != nil {
-		response := responses.ErrorsResponse(http.StatusInternalServerError, "Failed to process request", updateCategoryErr.Error(), nil)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		c.Error(updateCategoryErr)
 		return
 	}
 
-	response := responses.SuccessResponse(http.StatusOK, "Update Success", updateCategory)
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Update Success", updateCategory)
 	c.JSON(http.StatusOK, response)
 	return
 }
 
 // @Summary	"Delete a single category"
+// @Description	"The category and its tasks are moved to the trash"
 // @Tags	"Category"
 // @Version	1.0
 // @Produce	application/json
@@ -194,6 +200,7 @@ func (h *categoryController) Update(c *gin.Context) {
 // @Param	id				path		integer	true	"Category ID"									minimum(1)
 // @Success	200 object responses.Response{errors=string,data=string} "Delete Success"
 // @Failure	400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure	403 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Failure	404 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Failure	500 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Router	/category/{id} [delete]
@@ -201,31 +208,27 @@ func (h *categoryController) Delete(c *gin.Context) {
 	var input request.CategoryGetRequest
 	err := c.ShouldBindUri(&input)
 	if err != nil {
-		response := responses.ErrorsResponseByCode(http.StatusBadRequest, "Failed to process request", responses.IdInvalid, nil)
-		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		c.Error(apperr.ErrIdInvalid)
 		return
 	}
 
-	category, categoryErr := h.categoryEntity.GetCategory(input.Id)
+	category, categoryErr := h.categoryEntity.GetCategory(input.Id, GetAuthUserID(c))
 	if categoryErr != nil {
-		response := responses.ErrorsResponse(http.StatusInternalServerError, "Failed to process request", categoryErr.Error(), nil)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		c.Error(categoryErr)
 		return
 	}
-	if category.ID == 0 {
-		response := responses.ErrorsResponseByCode(http.StatusNotFound, "Failed to process request", responses.RecordNotFound, nil)
-		c.AbortWithStatusJSON(http.StatusNotFound, response)
+	if category.IsSystem() {
+		c.Error(entity.ErrSystemCategoryReadOnly)
 		return
 	}
 
-	_, deleteCategoryErr := h.categoryEntity.DeleteCategory(input.Id)
+	_, deleteCategoryErr := h.categoryEntity.DeleteCategory(input.Id, GetAuthUserID(c))
 	if deleteCategoryErr != nil {
-		response := responses.ErrorsResponse(http.StatusInternalServerError, "Failed to process request", deleteCategoryErr.Error(), nil)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		c.Error(deleteCategoryErr)
 		return
 	}
 
-	response := responses.SuccessResponse(http.StatusOK, "Delete Success", nil)
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Delete Success", nil)
 	c.JSON(http.StatusOK, response)
 	return
 }
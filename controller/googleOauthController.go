@@ -1,7 +1,6 @@
 package controller
 
 import (
-	"go-todolist/model"
 	"go-todolist/services"
 	"go-todolist/utils/responses"
 	"io/ioutil"
@@ -60,26 +59,25 @@ func GoogleLogin(c *gin.Context) {
 }
 
 func (h *googleOauthController) GoogleCallBack(c *gin.Context) {
-	var responseToken model.Token
 	googleOAuthConfig.ClientSecret = os.Getenv("GOOGLE_OAUTH_CLIENT_SECRET")
 	code := c.Query("code")
 	state := c.Query("state")
 	if state != googleState {
-		response := responses.ErrorsResponseByCode(http.StatusBadRequest, "Failed to GoogleCallBack", responses.FailedToGetStateToken, nil)
+		response := responses.ErrorsResponseByCode(GetLocale(c), http.StatusBadRequest, "Failed to GoogleCallBack", responses.FailedToGetStateToken, nil)
 		c.AbortWithStatusJSON(http.StatusBadRequest, response)
 		return
 	}
 
 	token, err := googleOAuthConfig.Exchange(oauth2.NoContext, code)
 	if err != nil {
-		response := responses.ErrorsResponse(http.StatusBadRequest, "Failed to GoogleCallBack", err.Error(), nil)
+		response := responses.ErrorsResponse(GetLocale(c), http.StatusBadRequest, "Failed to GoogleCallBack", err.Error(), nil)
 		c.AbortWithStatusJSON(http.StatusBadRequest, response)
 		return
 	}
 
 	resp, err := http.Get("https://www.googleapis.com/oauth2/v2/userinfo?access_token=" + token.AccessToken)
 	if err != nil {
-		response := responses.ErrorsResponse(http.StatusBadRequest, "Failed to GoogleCallBack", err.Error(), nil)
+		response := responses.ErrorsResponse(GetLocale(c), http.StatusBadRequest, "Failed to GoogleCallBack", err.Error(), nil)
 		c.AbortWithStatusJSON(http.StatusBadRequest, response)
 		return
 	}
@@ -87,7 +85,7 @@ func (h *googleOauthController) GoogleCallBack(c *gin.Context) {
 
 	content, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
-		response := responses.ErrorsResponse(http.StatusBadRequest, "Failed to GoogleCallBack", err.Error(), nil)
+		response := responses.ErrorsResponse(GetLocale(c), http.StatusBadRequest, "Failed to GoogleCallBack", err.Error(), nil)
 		c.AbortWithStatusJSON(http.StatusBadRequest, response)
 		return
 	}
@@ -97,15 +95,14 @@ func (h *googleOauthController) GoogleCallBack(c *gin.Context) {
 		Email: gjson.GetBytes(content, "email").String(),
 	}
 
-	generatorToken := h.jwtService.GoogleGenerateToken(data)
-	if len(generatorToken) < 1 {
-		response := responses.ErrorsResponseByCode(http.StatusBadRequest, "Failed to GoogleCallBack", responses.EmailNotExists, nil)
+	responseToken := h.jwtService.GoogleGenerateToken(data, NewSession(c, ""))
+	if len(responseToken.Token) < 1 {
+		response := responses.ErrorsResponseByCode(GetLocale(c), http.StatusBadRequest, "Failed to GoogleCallBack", responses.EmailNotExists, nil)
 		c.AbortWithStatusJSON(http.StatusBadRequest, response)
 		return
 	}
-	responseToken.Token = generatorToken
 
-	response := responses.SuccessResponse(http.StatusOK, "Google access success", responseToken)
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Google access success", responseToken)
 	c.AbortWithStatusJSON(http.StatusOK, response)
 	return
 }
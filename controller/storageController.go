@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/responses"
+	"go-todolist/utils/storage"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StorageController interface {
+	Download(c *gin.Context)
+	Upload(c *gin.Context)
+}
+
+type storageController struct {
+	config storage.Config
+}
+
+func NewStorageController(config storage.Config) StorageController {
+	return &storageController{
+		config: config,
+	}
+}
+
+// @Summary		"Download a file of the local storage"
+// @Description	"Only available with STORAGE_DRIVER=local, the link is signed and expires"
+// @Tags		"Storage"
+// @Version		1.0
+// @Produce		application/octet-stream
+// @Param		key			path	string	true	"Object key (uuid/filename)"
+// @Param		expires		query	integer	true	"Expiry (unix time)"
+// @Param		signature	query	string	true	"Signature"
+// @Success		200 {file} file "File"
+// @Failure		403 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/storage/{key} [get]
+func (h *storageController) Download(c *gin.Context) {
+	if h.config.Driver != storage.DriverLocal {
+		c.Error(apperr.ErrRecordNotFound)
+		return
+	}
+
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	expires, _ := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if !h.config.Verify(http.MethodGet, key, expires, c.Query("signature")) {
+		c.Error(storage.ErrSignedURLInvalid)
+		return
+	}
+
+	path, pathErr := h.config.LocalFile(key)
+	if pathErr != nil {
+		c.Error(storage.ErrSignedURLInvalid)
+		return
+	}
+
+	if _, statErr := os.Stat(path); statErr != nil {
+		c.Error(apperr.ErrRecordNotFound)
+		return
+	}
+
+	c.File(path)
+}
+
+// @Summary		"Upload a file to the local storage"
+// @Description	"Only available with STORAGE_DRIVER=local, the pre-signed upload link of an attachment points here"
+// @Tags		"Storage"
+// @Version		1.0
+// @Accept		application/octet-stream
+// @Produce		application/json
+// @Param		key			path	string	true	"Object key (uuid/filename)"
+// @Param		expires		query	integer	true	"Expiry (unix time)"
+// @Param		signature	query	string	true	"Signature"
+// @Success		200 object responses.Response{errors=string,data=string} "Upload Success"
+// @Failure		400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		403 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/storage/{key} [put]
+func (h *storageController) Upload(c *gin.Context) {
+	if h.config.Driver != storage.DriverLocal {
+		c.Error(apperr.ErrRecordNotFound)
+		return
+	}
+
+	key := strings.TrimPrefix(c.Param("key"), "/")
+	expires, _ := strconv.ParseInt(c.Query("expires"), 10, 64)
+	if !h.config.Verify(http.MethodPut, key, expires, c.Query("signature")) {
+		c.Error(storage.ErrSignedURLInvalid)
+		return
+	}
+
+	path, pathErr := h.config.LocalFile(key)
+	if pathErr != nil {
+		c.Error(storage.ErrSignedURLInvalid)
+		return
+	}
+
+	mkdirErr := os.MkdirAll(filepath.Dir(path), 0755)
+	if mkdirErr != nil {
+		c.Error(mkdirErr)
+		return
+	}
+
+	dst, createErr := os.Create(path)
+	if createErr != nil {
+		c.Error(createErr)
+		return
+	}
+	defer dst.Close()
+
+	// The size is checked again when the upload is confirmed, this only stops oversized bodies early
+	written, copyErr := io.Copy(dst, io.LimitReader(c.Request.Body, h.config.Limits.MaxFileSize+1))
+	if copyErr == nil && written > h.config.Limits.MaxFileSize {
+		os.Remove(path)
+		c.Error(storage.ErrFileTooLarge)
+		return
+	}
+	if copyErr != nil {
+		os.Remove(path)
+		c.Error(copyErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Upload Success", nil)
+	c.JSON(http.StatusOK, response)
+	return
+}
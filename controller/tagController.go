@@ -0,0 +1,317 @@
+package controller
+
+import (
+	"go-todolist/entity"
+	"go-todolist/request"
+	"go-todolist/services"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/responses"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagController interface {
+	Create(c *gin.Context)
+	GetByList(c *gin.Context)
+	Get(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	Attach(c *gin.Context)
+	Detach(c *gin.Context)
+}
+
+type tagController struct {
+	tagService services.TagService
+	tagEntity  entity.TagEntity
+	taskEntity entity.TaskEntity
+	// sign the download links of the attachments
+	taskAttachmentService services.TaskAttachmentService
+}
+
+func NewTagController(tagService services.TagService, tagEntity entity.TagEntity, taskEntity entity.TaskEntity, taskAttachmentService services.TaskAttachmentService) TagController {
+	return &tagController{
+		tagService:            tagService,
+		tagEntity:             tagEntity,
+		taskEntity:            taskEntity,
+		taskAttachmentService: taskAttachmentService,
+	}
+}
+
+// @Summary "Create tag"
+// @Tags	"Tag"
+// @Version 1.0
+// @Produce application/json
+// @Param	Authorization	header		string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	name			formData	string	true	"Tag Name"										maxLength(50)
+// @Param	color			formData	string	false	"Color (#rrggbb)"								example(#ff5722)
+// @Success 201 object responses.Response{errors=string,data=model.Tag} "Create Success"
+// @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 409 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/tag [post]
+func (h *tagController) Create(c *gin.Context) {
+	var input request.TagCreateRequest
+	err := c.ShouldBind(&input)
+	if err != nil {
+		c.Error(apperr.Wrap(apperr.Validation, err))
+		return
+	}
+
+	createTag, createTagErr := h.tagService.CreateTag(input, GetAuthUserID(c))
+	if createTagErr != nil {
+		c.Error(createTagErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusCreated, "Create Success", createTag)
+	c.JSON(http.StatusCreated, response)
+	return
+}
+
+// @Summary "Tag list"
+// @Tags	"Tag"
+// @Version 1.0
+// @Produce application/json
+// @Param	Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	name			query	string	false	"Tag Name"										maxLength(50)
+// @Param	page			query	integer	true	"Page"											minimum(1) default(1)
+// @Param	limit			query	integer	true	"Limit"											minimum(2) default(5)
+// @Success 200 object responses.PageResponse{errors=string,data=[]model.Tag} "Successfully get tag list"
+// @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/tag [get]
+func (h *tagController) GetByList(c *gin.Context) {
+	var input request.TagGetListRequest
+	err := c.ShouldBind(&input)
+	if err != nil {
+		c.Error(apperr.Wrap(apperr.Validation, err))
+		return
+	}
+
+	tag := h.tagEntity.GetTagList(GetAuthUserID(c), input.Name, input.Page, input.Limit)
+	response := responses.SuccessPageResponse(GetLocale(c), http.StatusOK, "Successfully get tag list", tag.CurrentPage, tag.PageLimit, tag.Total, tag.Pages, tag.Data)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary	"Get a single tag"
+// @Tags	"Tag"
+// @Version	1.0
+// @Produce	application/json
+// @Param	Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	id				path	integer	true	"Tag ID"										minimum(1)
+// @Success	200 object responses.Response{errors=string,data=model.Tag} "Successfully get tag"
+// @Failure	400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure	404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure	500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/tag/{id} [get]
+func (h *tagController) Get(c *gin.Context) {
+	var input request.TagGetRequest
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	tag, tagErr := h.tagEntity.GetTag(input.Id, GetAuthUserID(c))
+	if tagErr != nil {
+		c.Error(tagErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Successfully get tag", tag)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary	"Update a single tag"
+// @Tags	"Tag"
+// @Version	1.0
+// @Produce	application/json
+// @Param	Authorization	header		string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	id				path		integer	true	"Tag ID"										minimum(1)
+// @Param	name			formData	string	false	"Tag Name"										maxLength(50)
+// @Param	color			formData	string	false	"Color (#rrggbb)"								example(#ff5722)
+// @Success	200 object responses.Response{errors=string,data=model.Tag} "Update Success"
+// @Failure	400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure	404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure	409 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure	500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/tag/{id} [PATCH]
+func (h *tagController) Update(c *gin.Context) {
+	var input request.TagUpdateRequest
+	var id request.TagGetRequest
+	err := c.ShouldBindUri(&id)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	inputErr := c.ShouldBind(&input)
+	if inputErr != nil {
+		c.Error(apperr.Wrap(apperr.Validation, inputErr))
+		return
+	}
+
+	tag, tagErr := h.tagEntity.GetTag(id.Id, GetAuthUserID(c))
+	if tagErr != nil {
+		c.Error(tagErr)
+		return
+	}
+
+	updateTag, updateTagErr := h.tagService.UpdateTag(input, tag.ID, GetAuthUserID(c))
+	if updateTagErr != nil {
+		c.Error(updateTagErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Update Success", updateTag)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary		"Delete a single tag"
+// @Description	"The tag is detached from its tasks"
+// @Tags		"Tag"
+// @Version		1.0
+// @Produce		application/json
+// @Param		Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param		id				path	integer	true	"Tag ID"										minimum(1)
+// @Success		200 object responses.Response{errors=string,data=string} "Delete Success"
+// @Failure		400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/tag/{id} [delete]
+func (h *tagController) Delete(c *gin.Context) {
+	var input request.TagGetRequest
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	tag, tagErr := h.tagEntity.GetTag(input.Id, GetAuthUserID(c))
+	if tagErr != nil {
+		c.Error(tagErr)
+		return
+	}
+
+	deleteErr := h.tagEntity.DeleteTag(tag.ID, GetAuthUserID(c))
+	if deleteErr != nil {
+		c.Error(deleteErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Delete Success", nil)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary "Attach tags to a task"
+// @Tags	"Task"
+// @Version 1.0
+// @Accept	application/x-www-form-urlencoded
+// @Produce application/json
+// @Param	Authorization	header		string		true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	id				path		integer		true	"Task ID"										minimum(1)
+// @Param	tag_id			formData	[]integer	true	"Tag IDs (repeated)"							collectionFormat(multi)
+// @Success 200 object responses.Response{errors=string,data=model.Task} "Update Success"
+// @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/task/{id}/tags [post]
+func (h *tagController) Attach(c *gin.Context) {
+	var input request.TaskTagAttachRequest
+	var id request.TaskGetRequest
+	err := c.ShouldBindUri(&id)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	inputErr := c.ShouldBind(&input)
+	if inputErr != nil {
+		c.Error(apperr.Wrap(apperr.Validation, inputErr))
+		return
+	}
+
+	task, taskErr := h.taskEntity.GetTask(id.Id, GetAuthUserID(c))
+	if taskErr != nil {
+		c.Error(taskErr)
+		return
+	}
+
+	// Every tag must be one of the user's tags
+	unique := map[int64]bool{}
+	for _, tagID := range input.TagID {
+		unique[tagID] = true
+	}
+	count, countErr := h.tagEntity.CountTags(input.TagID, GetAuthUserID(c))
+	if countErr != nil {
+		c.Error(countErr)
+		return
+	}
+	if count != int64(len(unique)) {
+		c.Error(entity.ErrTagNotFound)
+		return
+	}
+
+	attachErr := h.tagEntity.AttachTaskTags(task.ID, input.TagID)
+	if attachErr != nil {
+		c.Error(attachErr)
+		return
+	}
+
+	task, taskErr = h.taskEntity.GetTask(task.ID, GetAuthUserID(c))
+	if taskErr != nil {
+		c.Error(taskErr)
+		return
+	}
+
+	h.taskAttachmentService.SignTaskAttachments(task.Attachments)
+	task.Localize(GetTimezone(c))
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Update Success", task)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary "Detach a tag from a task"
+// @Tags	"Task"
+// @Version 1.0
+// @Produce application/json
+// @Param	Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	id				path	integer	true	"Task ID"										minimum(1)
+// @Param	tag_id			path	integer	true	"Tag ID"										minimum(1)
+// @Success 200 object responses.Response{errors=string,data=string} "Delete Success"
+// @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/task/{id}/tags/{tag_id} [delete]
+func (h *tagController) Detach(c *gin.Context) {
+	var input request.TaskTagGetRequest
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	task, taskErr := h.taskEntity.GetTask(input.Id, GetAuthUserID(c))
+	if taskErr != nil {
+		c.Error(taskErr)
+		return
+	}
+
+	detached, detachErr := h.tagEntity.DetachTaskTag(task.ID, input.TagID)
+	if detachErr != nil {
+		c.Error(detachErr)
+		return
+	}
+	if !detached {
+		c.Error(apperr.ErrRecordNotFound)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Delete Success", nil)
+	c.JSON(http.StatusOK, response)
+	return
+}
@@ -0,0 +1,316 @@
+package controller
+
+import (
+	"go-todolist/entity"
+	"go-todolist/request"
+	"go-todolist/services"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/responses"
+	"go-todolist/utils/storage"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaskAttachmentController interface {
+	Create(c *gin.Context)
+	GetByList(c *gin.Context)
+	Get(c *gin.Context)
+	Delete(c *gin.Context)
+	CreateUpload(c *gin.Context)
+	ConfirmUpload(c *gin.Context)
+}
+
+type taskAttachmentController struct {
+	taskAttachmentService services.TaskAttachmentService
+	taskAttachmentEntity  entity.TaskAttachmentEntity
+	taskEntity            entity.TaskEntity
+	limits                storage.Limits
+}
+
+func NewTaskAttachmentController(taskAttachmentService services.TaskAttachmentService, taskAttachmentEntity entity.TaskAttachmentEntity, taskEntity entity.TaskEntity, limits storage.Limits) TaskAttachmentController {
+	return &taskAttachmentController{
+		taskAttachmentService: taskAttachmentService,
+		taskAttachmentEntity:  taskAttachmentEntity,
+		taskEntity:            taskEntity,
+		limits:                limits,
+	}
+}
+
+// CheckFiles is a shared method for validate the uploaded files against the upload limits, it aborts the request if any file is invalid
+func CheckFiles(c *gin.Context, limits storage.Limits, files ...*multipart.FileHeader) bool {
+	for _, file := range files {
+		if err := limits.Check(file); err != nil {
+			c.Error(err)
+			return false
+		}
+	}
+
+	return true
+}
+
+// @Summary "Upload attachments of a task"
+// @Tags	"Task"
+// @Version 1.0
+// @Accept	multipart/form-data
+// @Produce application/json
+// @Param	Authorization	header		string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	id				path		integer	true	"Task ID"										minimum(1)
+// @Param	files			formData	file	true	"Files (multiple)"
+// @Success 201 object responses.Response{errors=string,data=[]model.TaskAttachment} "Create Success"
+// @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/task/{id}/attachments [post]
+func (h *taskAttachmentController) Create(c *gin.Context) {
+	var input request.TaskAttachmentCreateRequest
+	var id request.TaskGetRequest
+	err := c.ShouldBindUri(&id)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	inputErr := c.ShouldBind(&input)
+	if inputErr != nil {
+		c.Error(apperr.Wrap(apperr.Validation, inputErr))
+		return
+	}
+
+	task, taskErr := h.taskEntity.GetTask(id.Id, GetAuthUserID(c))
+	if taskErr != nil {
+		c.Error(taskErr)
+		return
+	}
+
+	if !CheckFiles(c, h.limits, input.Files...) {
+		return
+	}
+
+	// The limit is enforced again as each file is recorded, this spares processing files which cannot fit
+	count, countErr := h.taskAttachmentService.CountTaskAttachments(task.ID)
+	if countErr != nil {
+		c.Error(countErr)
+		return
+	}
+	if count+int64(len(input.Files)) > int64(h.limits.MaxFiles) {
+		c.Error(services.ErrTooManyAttachments)
+		return
+	}
+
+	attachments, attachmentsErr := h.taskAttachmentService.CreateTaskAttachments(task.ID, input.Files)
+	if attachmentsErr != nil {
+		c.Error(attachmentsErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusCreated, "Create Success", attachments)
+	c.JSON(http.StatusCreated, response)
+	return
+}
+
+// @Summary "Attachment list of a task"
+// @Tags	"Task"
+// @Version 1.0
+// @Produce application/json
+// @Param	Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	id				path	integer	true	"Task ID"										minimum(1)
+// @Success 200 object responses.Response{errors=string,data=[]model.TaskAttachment} "Successfully get task attachment list"
+// @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/task/{id}/attachments [get]
+func (h *taskAttachmentController) GetByList(c *gin.Context) {
+	var id request.TaskGetRequest
+	err := c.ShouldBindUri(&id)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	task, taskErr := h.taskEntity.GetTask(id.Id, GetAuthUserID(c))
+	if taskErr != nil {
+		c.Error(taskErr)
+		return
+	}
+
+	attachments, attachmentsErr := h.taskAttachmentEntity.GetTaskAttachmentList(task.ID)
+	if attachmentsErr != nil {
+		c.Error(attachmentsErr)
+		return
+	}
+	h.taskAttachmentService.SignTaskAttachments(attachments)
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Successfully get task attachment list", attachments)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary		"Download an attachment of a task"
+// @Description	"Redirects to a temporary download link of the file"
+// @Tags		"Task"
+// @Version		1.0
+// @Produce		application/json
+// @Param		Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param		id				path	integer	true	"Task ID"										minimum(1)
+// @Param		attachment_id	path	integer	true	"Attachment ID"									minimum(1)
+// @Success		302 "Redirect to the file"
+// @Failure		400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/task/{id}/attachments/{attachment_id} [get]
+func (h *taskAttachmentController) Get(c *gin.Context) {
+	var input request.TaskAttachmentGetRequest
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	task, taskErr := h.taskEntity.GetTask(input.Id, GetAuthUserID(c))
+	if taskErr != nil {
+		c.Error(taskErr)
+		return
+	}
+
+	attachment, attachmentErr := h.taskAttachmentEntity.GetTaskAttachment(input.AttachmentID, task.ID)
+	if attachmentErr != nil {
+		c.Error(attachmentErr)
+		return
+	}
+
+	url, urlErr := h.taskAttachmentService.GetTaskAttachmentURL(attachment)
+	if urlErr != nil {
+		c.Error(urlErr)
+		return
+	}
+
+	c.Redirect(http.StatusFound, url)
+}
+
+// @Summary "Delete an attachment of a task"
+// @Tags	"Task"
+// @Version 1.0
+// @Produce application/json
+// @Param	Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	id				path	integer	true	"Task ID"										minimum(1)
+// @Param	attachment_id	path	integer	true	"Attachment ID"									minimum(1)
+// @Success 200 object responses.Response{errors=string,data=string} "Delete Success"
+// @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/task/{id}/attachments/{attachment_id} [delete]
+func (h *taskAttachmentController) Delete(c *gin.Context) {
+	var input request.TaskAttachmentGetRequest
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	task, taskErr := h.taskEntity.GetTask(input.Id, GetAuthUserID(c))
+	if taskErr != nil {
+		c.Error(taskErr)
+		return
+	}
+
+	attachment, attachmentErr := h.taskAttachmentEntity.GetTaskAttachment(input.AttachmentID, task.ID)
+	if attachmentErr != nil {
+		c.Error(attachmentErr)
+		return
+	}
+
+	deleteErr := h.taskAttachmentService.DeleteTaskAttachment(attachment)
+	if deleteErr != nil {
+		c.Error(deleteErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Delete Success", nil)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary		"Request a pre-signed URL to upload an attachment of a task"
+// @Description	"Upload the file with a PUT request to the returned url (with the returned headers) before it expires, then confirm the upload"
+// @Tags		"Task"
+// @Version		1.0
+// @Accept		application/json
+// @Produce		application/json
+// @Param		Authorization	header	string								true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param		id				path	integer								true	"Task ID"										minimum(1)
+// @Param		*				body	request.TaskAttachmentUploadRequest	true	"File to upload"
+// @Success		201 object responses.Response{errors=string,data=model.TaskAttachmentUpload} "Create Success"
+// @Failure		400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/task/{id}/attachments/uploads [post]
+func (h *taskAttachmentController) CreateUpload(c *gin.Context) {
+	var input request.TaskAttachmentUploadRequest
+	var id request.TaskGetRequest
+	err := c.ShouldBindUri(&id)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	inputErr := c.ShouldBind(&input)
+	if inputErr != nil {
+		c.Error(apperr.Wrap(apperr.Validation, inputErr))
+		return
+	}
+
+	task, taskErr := h.taskEntity.GetTask(id.Id, GetAuthUserID(c))
+	if taskErr != nil {
+		c.Error(taskErr)
+		return
+	}
+
+	upload, uploadErr := h.taskAttachmentService.CreateUploadURL(task.ID, input)
+	if uploadErr != nil {
+		c.Error(uploadErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusCreated, "Create Success", upload)
+	c.JSON(http.StatusCreated, response)
+	return
+}
+
+// @Summary "Confirm the upload of a pre-signed URL and record the attachment on the task"
+// @Tags	"Task"
+// @Version 1.0
+// @Produce application/json
+// @Param	Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	id				path	integer	true	"Task ID"										minimum(1)
+// @Param	uuid			path	string	true	"Upload uuid"
+// @Success 201 object responses.Response{errors=string,data=model.TaskAttachment} "Create Success"
+// @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/task/{id}/attachments/uploads/{uuid} [post]
+func (h *taskAttachmentController) ConfirmUpload(c *gin.Context) {
+	var input request.TaskAttachmentConfirmRequest
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	task, taskErr := h.taskEntity.GetTask(input.Id, GetAuthUserID(c))
+	if taskErr != nil {
+		c.Error(taskErr)
+		return
+	}
+
+	attachment, confirmErr := h.taskAttachmentService.ConfirmUpload(task.ID, input.Uuid)
+	if confirmErr != nil {
+		c.Error(confirmErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusCreated, "Create Success", attachment)
+	c.JSON(http.StatusCreated, response)
+	return
+}
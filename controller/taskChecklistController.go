@@ -0,0 +1,324 @@
+package controller
+
+import (
+	"go-todolist/entity"
+	"go-todolist/model"
+	"go-todolist/request"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/responses"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaskChecklistController interface {
+	Create(c *gin.Context)
+	GetByList(c *gin.Context)
+	Update(c *gin.Context)
+	Toggle(c *gin.Context)
+	Delete(c *gin.Context)
+	Reorder(c *gin.Context)
+}
+
+type taskChecklistController struct {
+	taskChecklistEntity entity.TaskChecklistEntity
+	taskEntity          entity.TaskEntity
+}
+
+func NewTaskChecklistController(taskChecklistEntity entity.TaskChecklistEntity, taskEntity entity.TaskEntity) TaskChecklistController {
+	return &taskChecklistController{
+		taskChecklistEntity: taskChecklistEntity,
+		taskEntity:          taskEntity,
+	}
+}
+
+// getTask returns the task of the user in the uri, it aborts the request if the task is not found
+func (h *taskChecklistController) getTask(c *gin.Context, id int64) (model.Task, bool) {
+	task, taskErr := h.taskEntity.GetTask(id, GetAuthUserID(c))
+	if taskErr != nil {
+		c.Error(taskErr)
+		return task, false
+	}
+
+	return task, true
+}
+
+// getItem returns the checklist item of the task in the uri, it aborts the request if the item is not found
+func (h *taskChecklistController) getItem(c *gin.Context, input request.TaskChecklistItemGetRequest) (model.TaskChecklistItem, bool) {
+	task, ok := h.getTask(c, input.Id)
+	if !ok {
+		return model.TaskChecklistItem{}, false
+	}
+
+	item, itemErr := h.taskChecklistEntity.GetTaskChecklistItem(input.ItemID, task.ID)
+	if itemErr != nil {
+		c.Error(itemErr)
+		return item, false
+	}
+
+	return item, true
+}
+
+// @Summary "Add an item to the checklist of a task"
+// @Tags	"Task"
+// @Version 1.0
+// @Accept	application/x-www-form-urlencoded
+// @Produce application/json
+// @Param	Authorization	header		string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	id				path		integer	true	"Task ID"										minimum(1)
+// @Param	title			formData	string	true	"Title"											maxlength(255)
+// @Success 201 object responses.Response{errors=string,data=model.TaskChecklistItem} "Create Success"
+// @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/task/{id}/checklist [post]
+func (h *taskChecklistController) Create(c *gin.Context) {
+	var input request.TaskChecklistItemCreateRequest
+	var id request.TaskGetRequest
+	err := c.ShouldBindUri(&id)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	inputErr := c.ShouldBind(&input)
+	if inputErr != nil {
+		c.Error(apperr.Wrap(apperr.Validation, inputErr))
+		return
+	}
+
+	task, ok := h.getTask(c, id.Id)
+	if !ok {
+		return
+	}
+
+	item, itemErr := h.taskChecklistEntity.CreateTaskChecklistItem(model.TaskChecklistItem{TaskID: task.ID, Title: input.Title})
+	if itemErr != nil {
+		c.Error(itemErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusCreated, "Create Success", item)
+	c.JSON(http.StatusCreated, response)
+	return
+}
+
+// @Summary "Checklist of a task"
+// @Tags	"Task"
+// @Version 1.0
+// @Produce application/json
+// @Param	Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	id				path	integer	true	"Task ID"										minimum(1)
+// @Success 200 object responses.Response{errors=string,data=[]model.TaskChecklistItem} "Successfully get task checklist"
+// @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/task/{id}/checklist [get]
+func (h *taskChecklistController) GetByList(c *gin.Context) {
+	var id request.TaskGetRequest
+	err := c.ShouldBindUri(&id)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	task, ok := h.getTask(c, id.Id)
+	if !ok {
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Successfully get task checklist", task.Checklist)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary "Update an item of the checklist of a task"
+// @Tags	"Task"
+// @Version 1.0
+// @Accept	application/x-www-form-urlencoded
+// @Produce application/json
+// @Param	Authorization	header		string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	id				path		integer	true	"Task ID"										minimum(1)
+// @Param	item_id			path		integer	true	"Checklist item ID"								minimum(1)
+// @Param	title			formData	string	false	"Title"											minlength(1)	maxlength(255)
+// @Param	is_done			formData	boolean	false	"Is Done"
+// @Success 200 object responses.Response{errors=string,data=model.TaskChecklistItem} "Update Success"
+// @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/task/{id}/checklist/{item_id} [patch]
+func (h *taskChecklistController) Update(c *gin.Context) {
+	var input request.TaskChecklistItemUpdateRequest
+	var id request.TaskChecklistItemGetRequest
+	err := c.ShouldBindUri(&id)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	inputErr := c.ShouldBind(&input)
+	if inputErr != nil {
+		c.Error(apperr.Wrap(apperr.Validation, inputErr))
+		return
+	}
+
+	item, ok := h.getItem(c, id)
+	if !ok {
+		return
+	}
+
+	values := map[string]interface{}{}
+	if input.Title != nil {
+		values["title"] = *input.Title
+	}
+	if input.IsDone != nil {
+		values["is_done"] = *input.IsDone
+	}
+	if len(values) > 0 {
+		var itemErr error
+		item, itemErr = h.taskChecklistEntity.UpdateTaskChecklistItem(item.ID, item.TaskID, values)
+		if itemErr != nil {
+			c.Error(itemErr)
+			return
+		}
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Update Success", item)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary "Check or uncheck an item of the checklist of a task"
+// @Tags	"Task"
+// @Version 1.0
+// @Produce application/json
+// @Param	Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	id				path	integer	true	"Task ID"										minimum(1)
+// @Param	item_id			path	integer	true	"Checklist item ID"								minimum(1)
+// @Success 200 object responses.Response{errors=string,data=model.TaskChecklistItem} "Update Success"
+// @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/task/{id}/checklist/{item_id}/toggle [post]
+func (h *taskChecklistController) Toggle(c *gin.Context) {
+	var id request.TaskChecklistItemGetRequest
+	err := c.ShouldBindUri(&id)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	item, ok := h.getItem(c, id)
+	if !ok {
+		return
+	}
+
+	item, itemErr := h.taskChecklistEntity.UpdateTaskChecklistItem(item.ID, item.TaskID, map[string]interface{}{"is_done": !item.IsDone})
+	if itemErr != nil {
+		c.Error(itemErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Update Success", item)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary "Delete an item of the checklist of a task"
+// @Tags	"Task"
+// @Version 1.0
+// @Produce application/json
+// @Param	Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	id				path	integer	true	"Task ID"										minimum(1)
+// @Param	item_id			path	integer	true	"Checklist item ID"								minimum(1)
+// @Success 200 object responses.Response{errors=string,data=string} "Delete Success"
+// @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/task/{id}/checklist/{item_id} [delete]
+func (h *taskChecklistController) Delete(c *gin.Context) {
+	var id request.TaskChecklistItemGetRequest
+	err := c.ShouldBindUri(&id)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	item, ok := h.getItem(c, id)
+	if !ok {
+		return
+	}
+
+	deleteErr := h.taskChecklistEntity.DeleteTaskChecklistItem(item.ID, item.TaskID)
+	if deleteErr != nil {
+		c.Error(deleteErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Delete Success", nil)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary		"Reorder the checklist of a task"
+// @Description	"item_ids must contain every item of the checklist exactly once, in the new order"
+// @Tags		"Task"
+// @Version		1.0
+// @Accept		application/json
+// @Produce		application/json
+// @Param		Authorization	header	string								true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param		id				path	integer								true	"Task ID"										minimum(1)
+// @Param		*				body	request.TaskChecklistReorderRequest	true	"Item IDs in the new order"
+// @Success		200 object responses.Response{errors=string,data=[]model.TaskChecklistItem} "Update Success"
+// @Failure		400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/task/{id}/checklist/order [put]
+func (h *taskChecklistController) Reorder(c *gin.Context) {
+	var input request.TaskChecklistReorderRequest
+	var id request.TaskGetRequest
+	err := c.ShouldBindUri(&id)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	inputErr := c.ShouldBind(&input)
+	if inputErr != nil {
+		c.Error(apperr.Wrap(apperr.Validation, inputErr))
+		return
+	}
+
+	task, ok := h.getTask(c, id.Id)
+	if !ok {
+		return
+	}
+
+	// The new order must be a permutation of the current items
+	current := map[int64]bool{}
+	for _, item := range task.Checklist {
+		current[item.ID] = true
+	}
+	valid := len(input.ItemIDs) == len(current)
+	for _, itemID := range input.ItemIDs {
+		if !current[itemID] {
+			valid = false
+			break
+		}
+		delete(current, itemID)
+	}
+	if !valid {
+		c.Error(entity.ErrChecklistOrderInvalid)
+		return
+	}
+
+	items, itemsErr := h.taskChecklistEntity.ReorderTaskChecklist(task.ID, input.ItemIDs)
+	if itemsErr != nil {
+		c.Error(itemsErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Update Success", items)
+	c.JSON(http.StatusOK, response)
+	return
+}
@@ -2,11 +2,18 @@ package controller
 
 import (
 	"go-todolist/entity"
+	"go-todolist/model"
 	"go-todolist/request"
 	"go-todolist/services"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/civilDatetime"
+	"go-todolist/utils/paginator"
 	"go-todolist/utils/responses"
+	"go-todolist/utils/rrule"
+	"go-todolist/utils/search"
+	"go-todolist/utils/storage"
 	"net/http"
-	"regexp"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -17,23 +24,65 @@ type TaskController interface {
 	Get(c *gin.Context)
 	Update(c *gin.Context)
 	Delete(c *gin.Context)
+	Occurrences(c *gin.Context)
+	Move(c *gin.Context)
 }
 
 type taskController struct {
-	taskService services.TaskService
-	taskEntity  entity.TaskEntity
+	taskService    services.TaskService
+	taskEntity     entity.TaskEntity
+	categoryEntity entity.CategoryEntity
+	workflowEntity entity.WorkflowEntity
+	// sign the download links of the attachments
+	taskAttachmentService services.TaskAttachmentService
+	// inject upload limits
+	limits storage.Limits
 }
 
-func NewTaskController(taskService services.TaskService, taskEntity entity.TaskEntity) TaskController {
+func NewTaskController(taskService services.TaskService, taskEntity entity.TaskEntity, categoryEntity entity.CategoryEntity, workflowEntity entity.WorkflowEntity, taskAttachmentService services.TaskAttachmentService, limits storage.Limits) TaskController {
 	return &taskController{
-		taskService: taskService,
-		taskEntity:  taskEntity,
+		taskService:           taskService,
+		taskEntity:            taskEntity,
+		categoryEntity:        categoryEntity,
+		workflowEntity:        workflowEntity,
+		taskAttachmentService: taskAttachmentService,
+		limits:                limits,
 	}
 }
 
-func (h *taskController) getUuid(user_id int64) interface{} {
-	task := h.taskEntity.GetTaskImguuidByUserId(user_id)
-	return task
+// categoryExists checks the category is one of the user's own or a system category
+func (h *taskController) categoryExists(category_id int64, user_id int64) bool {
+	category, _ := h.categoryEntity.GetCategory(category_id, user_id)
+	return category.ID != 0
+}
+
+// statusExists checks the status is one of the user's workflow statuses
+func (h *taskController) statusExists(status_id int64, user_id int64) bool {
+	status, _ := h.workflowEntity.GetWorkflowStatus(status_id, user_id)
+	return status.ID != 0
+}
+
+// parentValid checks the parent is one of the user's tasks and is not the task itself or one of its subtasks (id 0 for a new task)
+func (h *taskController) parentValid(parent_id int64, id int64, user_id int64) bool {
+	parent, _ := h.taskEntity.GetTask(parent_id, user_id)
+	if parent.ID == 0 || parent.ID == id {
+		return false
+	}
+	if id == 0 {
+		return true
+	}
+
+	descendants, err := h.taskEntity.GetTaskDescendantIDs(id, user_id)
+	if err != nil {
+		return false
+	}
+	for _, descendant := range descendants {
+		if descendant == parent_id {
+			return false
+		}
+	}
+
+	return true
 }
 
 // @Summary "Create task"
@@ -42,57 +91,70 @@ func (h *taskController) getUuid(user_id int64) interface{} {
 // @Accept	multipart/form-data
 // @Produce application/json
 // @Param	Authorization		header		string	true	"example:Bearer token (Bearer+space+token)."		default(Bearer )
-// @Param	user_id				formData	integer	true	"User ID"											minimum(1)
 // @Param	category_id			formData	integer	true	"Category ID"										minimum(1)
+// @Param	parent_id			formData	integer	false	"Parent task ID (subtask)"							minimum(1)
+// @Param	status_id			formData	integer	false	"Workflow status ID, the first status by default (is_complete follows the status)"	minimum(1)
 // @Param	title				formData	string	true	"Title"												maxLength(100)
 // @Param	note				formData	string	false	"Note"
 // @Param	url					formData	string	false	"Url"
-// @Param	image				formData	file	false	"Image"
-// @Param	specify_datetime	formData	string	false	"Specify Datetime (DateTime: 2006-01-02 15:04:05)"
-// @Param	is_specify_time		formData	boolean	false	"Is Specify Time"
+// @Param	image				formData	file	false	"Image (added as an attachment)"
+// @Param	specify_datetime	formData	string	false	"Specify Datetime (RFC 3339: 2006-01-02T15:04:05+08:00, or 2006-01-02 15:04:05 in the timezone of the user)"
+// @Param	specify_date		formData	string	false	"Specify Date of a task without a time, instead of specify_datetime (Date: 2006-01-02)"
+// @Param	is_specify_time		formData	boolean	false	"Is Specify Time (without it, only the date of specify_datetime is kept)"
+// @Param	rrule				formData	string	false	"Repeat rule (RFC 5545 RRULE, e.g. FREQ=WEEKLY;BYDAY=MO,WE;COUNT=10), requires specify_datetime or specify_date"	maxLength(255)
 // @Param	priority			formData	integer	true	"Priority"											Enums(1, 2, 3) default(1)
 // @Param	is_complete			formData	boolean	false	"Is Complete"										default(false)
 // @Success 201 object responses.Response{errors=string,data=string} "Create Success"
 // @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 409 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Router	/task [post]
 func (h *taskController) Create(c *gin.Context) {
 	var input request.TaskCreateRequest
 	err := c.ShouldBind(&input)
 	if err != nil {
-		response := responses.ErrorsResponse(http.StatusBadRequest, "Failed to process request", err.Error(), nil)
-		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		c.Error(apperr.Wrap(apperr.Validation, err))
 		return
 	}
 
-	if input.Image != nil {
-		if len(input.Image.Filename) > 100 {
-			response := responses.ErrorsResponseByCode(http.StatusBadRequest, "Failed to process request", responses.ImageFileNameLimitOf100, nil)
-			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+	if !h.categoryExists(input.CategoryID, GetAuthUserID(c)) {
+		c.Error(apperr.New(apperr.Validation, responses.CategoryNotFound))
+		return
+	}
+
+	if input.ParentID != nil && !h.parentValid(*input.ParentID, 0, GetAuthUserID(c)) {
+		c.Error(apperr.New(apperr.Validation, responses.ParentTaskInvalid))
+		return
+	}
+
+	if input.StatusID != nil && !h.statusExists(*input.StatusID, GetAuthUserID(c)) {
+		c.Error(services.ErrWorkflowStatusNotFound)
+		return
+	}
+
+	if input.RRule != nil && *input.RRule != "" {
+		if input.SpecifyDatetime == nil && input.SpecifyDate == nil {
+			c.Error(apperr.New(apperr.Validation, responses.RRuleRequiresSpecifyDatetime))
 			return
 		}
-		if input.Image.Size > (5 << 20) {
-			response := responses.ErrorsResponseByCode(http.StatusBadRequest, "Failed to process request", responses.ImageFileSizeLimitOf5MB, nil)
-			c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		if _, rruleErr := rrule.Parse(*input.RRule); rruleErr != nil {
+			c.Error(apperr.Wrap(apperr.Validation, rruleErr))
 			return
 		}
 	}
 
-	createTask, createTaskErr := h.taskService.CreateTask(input, nil)
+	if input.Image != nil && !CheckFiles(c, h.limits, input.Image) {
+		return
+	}
+
+	createTask, createTaskErr := h.taskService.CreateTask(input, GetAuthUserID(c), GetTimezone(c))
 	if createTaskErr != nil {
-		match, _ := regexp.MatchString("Duplicate", createTaskErr.Error())
-		if match {
-			response := responses.ErrorsResponse(http.StatusInternalServerError, "Failed to process request", "Error 1062: Duplicate entry "+input.Title, nil)
-			c.AbortWithStatusJSON(http.StatusInternalServerError, response)
-			return
-		} else {
-			response := responses.ErrorsResponse(http.StatusInternalServerError, "Failed to process request", createTaskErr.Error(), nil)
-			c.AbortWithStatusJSON(http.StatusInternalServerError, response)
-			return
-		}
+		c.Error(createTaskErr)
+		return
 	}
 
-	response := responses.SuccessResponse(http.StatusCreated, "Create Success", createTask)
+	createTask.Localize(GetTimezone(c))
+	response := responses.SuccessResponse(GetLocale(c), http.StatusCreated, "Create Success", createTask)
 	c.JSON(http.StatusCreated, response)
 	return
 }
@@ -103,63 +165,149 @@ func (h *taskController) Create(c *gin.Context) {
 // @Produce application/json
 // @Param	Authorization		header		string	true	"example:Bearer token (Bearer+space+token)."		default(Bearer )
 // @Param	id					formData	integer	false	"Task ID"											minimum(1)
-// @Param	user_id				formData	integer	false	"User ID"											minimum(1)
 // @Param	title				formData	string	false	"Title"												maxLength(100)
-// @Param	specify_datetime	formData	string	false	"Specify Datetime (DateTime: 2006-01-02 15:04:05)"
+// @Param	parent_id			query		integer	false	"Subtasks of the task"								minimum(1)
+// @Param	status_id			query		integer	false	"Tasks in the workflow status (sort=position for the board order)"	minimum(1)
+// @Param	q					query		string	false	"Search the words in the title, note and url, the most relevant first (highlights in the response)"	maxLength(255)
+// @Param	specify_datetime	formData	string	false	"Specify Datetime (RFC 3339, or 2006-01-02 15:04:05 in the timezone of the user)"
 // @Param	is_specify_time		formData	boolean	false	"Is Specify Time"
 // @Param	is_complete			formData	boolean	false	"Is Complete"
-// @Param	page				query		integer	true	"Page"												minimum(1) default(1)
+// @Param	blocked				query		boolean	false	"Blocked by an incomplete task (blocked=false: the actionable tasks)"
+// @Param	due_from			query		string	false	"Due from (RFC 3339, or 2006-01-02 15:04:05 in the timezone of the user, the date for the tasks without a time)"
+// @Param	due_to				query		string	false	"Due to (RFC 3339, or 2006-01-02 15:04:05 in the timezone of the user, the date for the tasks without a time)"
+// @Param	created_from		query		string	false	"Created from (RFC 3339, or 2006-01-02 15:04:05 in the timezone of the user)"
+// @Param	created_to			query		string	false	"Created to (RFC 3339, or 2006-01-02 15:04:05 in the timezone of the user)"
+// @Param	priority			query		[]integer	false	"Priorities (repeated, e.g. priority=2&priority=3)"	collectionFormat(multi) Enums(1, 2, 3)
+// @Param	category_id			query		[]integer	false	"Category IDs (repeated)"							collectionFormat(multi)
+// @Param	tag_id				query		[]integer	false	"Tag IDs (repeated)"								collectionFormat(multi)
+// @Param	tag_match			query		string	false	"Tasks with any or all of the tags"					Enums(any, all) default(any)
+// @Param	due					query		string	false	"Due preset in the timezone of the user (overdue: incomplete and past due, today, upcoming: from tomorrow)"	Enums(overdue, today, upcoming)
+// @Param	sort				query		string	false	"Sort fields separated by commas, - for descending (id, title, priority, specify_datetime, is_complete, position, created_at, updated_at)"	example(-priority,specify_datetime)
+// @Param	page				query		integer	false	"Page (without page, the cursor pagination is used)"	minimum(1)
 // @Param	limit				query		integer	true	"Limit"												minimum(2) default(5)
-// @Success 200 object responses.PageResponse{errors=string,data=string} "Successfully get task list"
+// @Param	cursor				query		string	false	"next_cursor or prev_cursor of the previous response, empty for the first page"
+// @Param	with_total			query		boolean	false	"Count the total with the cursor pagination"			default(false)
+// @Success 200 object responses.PageResponse{errors=string,data=[]model.Task} "Successfully get task list (page)"
+// @Success 200 object responses.CursorPageResponse{errors=string,data=[]model.Task} "Successfully get task list (cursor)"
 // @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Router	/task [get]
 func (h *taskController) GetByList(c *gin.Context) {
 	var input request.TaskGetListRequest
 	err := c.ShouldBind(&input)
 	if err != nil {
-		response := responses.ErrorsResponse(http.StatusBadRequest, "Failed to process request", err.Error(), nil)
-		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		c.Error(apperr.Wrap(apperr.Validation, err))
+		return
+	}
+
+	sort, sortErr := paginator.Sort(input.Sort, entity.TaskSortColumns)
+	if sortErr != nil {
+		c.Error(apperr.Wrap(apperr.Validation, sortErr))
+		return
+	}
+
+	filter := entity.TaskListFilter{
+		ID:            input.Id,
+		UserID:        GetAuthUserID(c),
+		ParentID:      input.ParentID,
+		StatusID:      input.StatusID,
+		Title:         input.Title,
+		Q:             input.Q,
+		Priorities:    input.Priority,
+		CategoryIDs:   input.CategoryID,
+		TagIDs:        input.TagID,
+		TagMatch:      input.TagMatch,
+		IsSpecifyTime: input.IsSpecifyTime,
+		IsComplete:    input.IsComplete,
+		IsBlocked:     input.Blocked,
+		Due:           input.Due,
+		Sort:          sort,
+		Location:      GetTimezone(c),
+	}
+
+	// The datetimes without a zone are in the timezone of the user
+	datetimes := []struct {
+		value  *string
+		filter **time.Time
+	}{
+		{input.SpecifyDatetime, &filter.SpecifyDatetime},
+		{input.DueFrom, &filter.DueFrom},
+		{input.DueTo, &filter.DueTo},
+		{input.CreatedFrom, &filter.CreatedFrom},
+		{input.CreatedTo, &filter.CreatedTo},
+	}
+	for _, datetime := range datetimes {
+		if datetime.value == nil || *datetime.value == "" {
+			continue
+		}
+		t, parseErr := civilDatetime.ParseDatetime(*datetime.value, filter.Location)
+		if parseErr != nil {
+			c.Error(apperr.Wrap(apperr.Validation, parseErr))
+			return
+		}
+		*datetime.filter = &t
+	}
+
+	if input.IsCursor() {
+		task, taskErr := h.taskEntity.GetTaskListByCursor(filter, input.Cursor, input.Limit, input.WithTotal)
+		if taskErr != nil {
+			c.Error(taskErr)
+			return
+		}
+
+		h.prepareTaskList(task.Data, input.Q, filter.Location)
+		response := responses.SuccessCursorPageResponse(GetLocale(c), http.StatusOK, "Successfully get task list", task.PageLimit, task.Total, task.NextCursor, task.PrevCursor, task.Data)
+		c.JSON(http.StatusOK, response)
 		return
 	}
 
-	task := h.taskEntity.GetTaskList(input.Id, input.UserID, input.Title, input.SpecifyDatetime, input.IsSpecifyTime, input.IsComplete, input.Page, input.Limit)
-	response := responses.SuccessPageResponse(http.StatusOK, "Successfully get task list", task.CurrentPage, task.PageLimit, task.Total, task.Pages, task.Data)
+	task := h.taskEntity.GetTaskList(filter, input.Page, input.Limit)
+	h.prepareTaskList(task.Data, input.Q, filter.Location)
+	response := responses.SuccessPageResponse(GetLocale(c), http.StatusOK, "Successfully get task list", task.CurrentPage, task.PageLimit, task.Total, task.Pages, task.Data)
 	c.JSON(http.StatusOK, response)
 	return
 }
 
+// prepareTaskList signs the attachments of the listed tasks, converts their datetimes to the timezone of the user
+// and highlights the search terms
+func (h *taskController) prepareTaskList(tasks []model.Task, q string, loc *time.Location) {
+	terms := search.Terms(q)
+	for i := range tasks {
+		h.taskAttachmentService.SignTaskAttachments(tasks[i].Attachments)
+		tasks[i].Localize(loc)
+		if len(terms) > 0 {
+			tasks[i].Highlight(terms)
+		}
+	}
+}
+
 // @Summary "Get a single task"
 // @Tags	"Task"
 // @Version 1.0
 // @Produce application/json
 // @Param	Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
 // @Param	id				path	integer	true	"Task ID"										minimum(1)
-// @Success 200 object responses.Response{errors=string,data=string} "Record not found || Successfully get task"
+// @Success 200 object responses.Response{errors=string,data=string} "Successfully get task"
 // @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 404 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Router	/task/{id} [get]
 func (h *taskController) Get(c *gin.Context) {
 	var input request.TaskGetRequest
 	err := c.ShouldBindUri(&input)
 	if err != nil {
-		response := responses.ErrorsResponseByCode(http.StatusBadRequest, "Failed to process request", responses.IdInvalid, nil)
-		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		c.Error(apperr.ErrIdInvalid)
 		return
 	}
 
-	task, taskErr := h.taskEntity.GetTask(input.Id)
-	if task.ID == 0 {
-		response := responses.SuccessResponse(http.StatusOK, "Record not found", nil)
-		c.JSON(http.StatusOK, response)
-		return
-	}
+	task, taskErr := h.taskEntity.GetTask(input.Id, GetAuthUserID(c))
 	if taskErr != nil {
-		response := responses.ErrorsResponse(http.StatusInternalServerError, "Failed to process request", taskErr.Error(), nil)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		c.Error(taskErr)
 		return
 	}
 
-	response := responses.SuccessResponse(http.StatusOK, "Successfully get task", task)
+	h.taskAttachmentService.SignTaskAttachments(task.Attachments)
+	task.Localize(GetTimezone(c))
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Successfully get task", task)
 	c.JSON(http.StatusOK, response)
 	return
 }
@@ -172,17 +320,22 @@ func (h *taskController) Get(c *gin.Context) {
 // @Param	Authorization		header		string	true	"example:Bearer token (Bearer+space+token)."		default(Bearer )
 // @Param	id					path		integer	true	"Task ID"											minimum(1)
 // @Param	category_id			formData	integer	false	"Category ID"										minimum(1)
+// @Param	parent_id			formData	integer	false	"Parent task ID (subtask), 0 makes it a top-level task"	minimum(0)
 // @Param	title				formData	string	false	"Title"												maxLength(100)
 // @Param	note				formData	string	false	"Note"
 // @Param	url					formData	string	false	"Url"
-// @Param	image				formData	file	false	"Image"
-// @Param	specify_datetime	formData	string	false	"Specify Datetime (DateTime: 2006-01-02 15:04:05)"
-// @Param	is_specify_time		formData	boolean	false	"Is Specify Time"
+// @Param	image				formData	file	false	"Image (added as an attachment)"
+// @Param	specify_datetime	formData	string	false	"Specify Datetime (RFC 3339: 2006-01-02T15:04:05+08:00, or 2006-01-02 15:04:05 in the timezone of the user)"
+// @Param	specify_date		formData	string	false	"Specify Date of a task without a time, instead of specify_datetime (Date: 2006-01-02)"
+// @Param	is_specify_time		formData	boolean	false	"Is Specify Time (without it, only the date of specify_datetime is kept)"
+// @Param	rrule				formData	string	false	"Repeat rule (RFC 5545 RRULE), empty to remove it"	maxLength(255)
 // @Param	priority			formData	integer	true	"Priority"											Enums(1, 2, 3)
-// @Param	is_complete			formData	boolean	false	"Is Complete"
+// @Param	is_complete			formData	boolean	false	"Is Complete (moves the task to the first done status, completing a recurring task generates the next occurrence)"
+// @Param	complete_children	formData	boolean	false	"Complete the subtasks as well when the task is completed"	default(false)
 // @Success 200 object responses.Response{errors=string,data=string} "Update Success"
 // @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Failure 404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 409 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Router	/task/{id} [PATCH]
 func (h *taskController) Update(c *gin.Context) {
@@ -190,51 +343,73 @@ func (h *taskController) Update(c *gin.Context) {
 	var id request.TaskGetRequest
 	err := c.ShouldBindUri(&id)
 	if err != nil {
-		response := responses.ErrorsResponseByCode(http.StatusBadRequest, "Failed to process request", responses.IdInvalid, nil)
-		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		c.Error(apperr.ErrIdInvalid)
 		return
 	}
 
-	task, taskErr := h.taskEntity.GetTask(id.Id)
-	if task.ID == 0 {
-		response := responses.ErrorsResponseByCode(http.StatusNotFound, "Failed to process request", responses.RecordNotFound, nil)
-		c.AbortWithStatusJSON(http.StatusNotFound, response)
-		return
-	}
+	task, taskErr := h.taskEntity.GetTask(id.Id, GetAuthUserID(c))
 	if taskErr != nil {
-		response := responses.ErrorsResponse(http.StatusInternalServerError, "Failed to process request", taskErr.Error(), nil)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		c.Error(taskErr)
 		return
 	}
 
 	inputErr := c.ShouldBind(&input)
 	if inputErr != nil {
-		response := responses.ErrorsResponse(http.StatusBadRequest, "Failed to process request", inputErr.Error(), nil)
-		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		c.Error(apperr.Wrap(apperr.Validation, inputErr))
 		return
 	}
 
-	taskUuid := h.getUuid(task.UserID)
-	updateTask, updateTaskErr := h.taskService.UpdateTask(input, id.Id, task.UserID, task.Img, taskUuid)
-	if updateTaskErr != nil {
-		match, _ := regexp.MatchString("Duplicate", updateTaskErr.Error())
-		if match {
-			response := responses.ErrorsResponse(http.StatusInternalServerError, "Failed to process request", "Error 1062: Duplicate entry "+input.Title, nil)
-			c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+	if input.CategoryID > 0 && !h.categoryExists(input.CategoryID, GetAuthUserID(c)) {
+		c.Error(apperr.New(apperr.Validation, responses.CategoryNotFound))
+		return
+	}
+
+	if input.ParentID != nil && *input.ParentID > 0 && !h.parentValid(*input.ParentID, task.ID, GetAuthUserID(c)) {
+		c.Error(apperr.New(apperr.Validation, responses.ParentTaskInvalid))
+		return
+	}
+
+	if input.RRule != nil && *input.RRule != "" {
+		if input.SpecifyDatetime == nil && input.SpecifyDate == nil && task.SpecifyDatetime == nil {
+			c.Error(apperr.New(apperr.Validation, responses.RRuleRequiresSpecifyDatetime))
+			return
+		}
+		if _, rruleErr := rrule.Parse(*input.RRule); rruleErr != nil {
+			c.Error(apperr.Wrap(apperr.Validation, rruleErr))
 			return
-		} else {
-			response := responses.ErrorsResponse(http.StatusInternalServerError, "Failed to process request", updateTaskErr.Error(), nil)
-			c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		}
+	}
+
+	if input.Image != nil {
+		if !CheckFiles(c, h.limits, input.Image) {
+			return
+		}
+		count, countErr := h.taskAttachmentService.CountTaskAttachments(task.ID)
+		if countErr != nil {
+			c.Error(countErr)
+			return
+		}
+		if count >= int64(h.limits.MaxFiles) {
+			c.Error(services.ErrTooManyAttachments)
 			return
 		}
 	}
 
-	response := responses.SuccessResponse(http.StatusOK, "Update Success", updateTask)
+	updateTask, updateTaskErr := h.taskService.UpdateTask(input, task, GetTimezone(c))
+	if updateTaskErr != nil {
+		c.Error(updateTaskErr)
+		return
+	}
+
+	h.taskAttachmentService.SignTaskAttachments(updateTask.Attachments)
+	updateTask.Localize(GetTimezone(c))
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Update Success", updateTask)
 	c.JSON(http.StatusOK, response)
 	return
 }
 
 // @Summary "Delete a single task"
+// @Description "The task is moved to the trash"
 // @Tags	"Task"
 // @Version 1.0
 // @Produce application/json
@@ -249,31 +424,125 @@ func (h *taskController) Delete(c *gin.Context) {
 	var input request.TaskGetRequest
 	err := c.ShouldBindUri(&input)
 	if err != nil {
-		response := responses.ErrorsResponseByCode(http.StatusBadRequest, "Failed to process request", responses.IdInvalid, nil)
-		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	task, taskErr := h.taskEntity.GetTask(input.Id, GetAuthUserID(c))
+	if taskErr != nil {
+		c.Error(taskErr)
+		return
+	}
+
+	_, deleteTaskErr := h.taskEntity.DeleteTask(input.Id, task.UserID)
+	if deleteTaskErr != nil {
+		c.Error(deleteTaskErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Delete Success", nil)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary "Completed occurrences of a recurring task"
+// @Tags	"Task"
+// @Version 1.0
+// @Produce application/json
+// @Param	Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	id				path	integer	true	"Task ID"										minimum(1)
+// @Param	page			query	integer	true	"Page"											minimum(1) default(1)
+// @Param	limit			query	integer	true	"Limit"											minimum(2) default(5)
+// @Success 200 object responses.PageResponse{errors=string,data=[]model.TaskOccurrence} "Successfully get task occurrence list"
+// @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/task/{id}/occurrences [get]
+func (h *taskController) Occurrences(c *gin.Context) {
+	var input request.TaskOccurrenceListRequest
+	var id request.TaskGetRequest
+	err := c.ShouldBindUri(&id)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
 		return
 	}
 
-	task, taskErr := h.taskEntity.GetTask(input.Id)
-	if task.ID == 0 {
-		response := responses.ErrorsResponseByCode(http.StatusNotFound, "Failed to process request", responses.RecordNotFound, nil)
-		c.AbortWithStatusJSON(http.StatusNotFound, response)
+	inputErr := c.ShouldBind(&input)
+	if inputErr != nil {
+		c.Error(apperr.Wrap(apperr.Validation, inputErr))
 		return
 	}
+
+	task, taskErr := h.taskEntity.GetTask(id.Id, GetAuthUserID(c))
 	if taskErr != nil {
-		response := responses.ErrorsResponse(http.StatusInternalServerError, "Failed to process request", taskErr.Error(), nil)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+		c.Error(taskErr)
 		return
 	}
 
-	_, deleteTaskErr := h.taskEntity.DeleteTask(input.Id)
-	if deleteTaskErr != nil {
-		response := responses.ErrorsResponse(http.StatusInternalServerError, "Failed to process request", deleteTaskErr.Error(), nil)
-		c.AbortWithStatusJSON(http.StatusInternalServerError, response)
+	occurrences := h.taskEntity.GetTaskOccurrenceList(task.ID, input.Page, input.Limit)
+	for i := range occurrences.Data {
+		occurrences.Data[i].Localize(GetTimezone(c), task.IsSpecifyTime)
+	}
+	response := responses.SuccessPageResponse(GetLocale(c), http.StatusOK, "Successfully get task occurrence list", occurrences.CurrentPage, occurrences.PageLimit, occurrences.Total, occurrences.Pages, occurrences.Data)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary		"Move a task to a workflow status"
+// @Description	"Moves the task to the position of the status column (the end by default) in one transaction, is_complete follows the status. Moving an incomplete recurring task to a done status completes its occurrence"
+// @Tags		"Task"
+// @Version		1.0
+// @Accept		application/x-www-form-urlencoded
+// @Produce		application/json
+// @Param		Authorization	header		string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param		id				path		integer	true	"Task ID"										minimum(1)
+// @Param		status_id		formData	integer	true	"Workflow status ID"							minimum(1)
+// @Param		position		formData	integer	false	"Position in the status column (from 1)"		minimum(1)
+// @Success		200 object responses.Response{errors=string,data=model.Task} "Update Success"
+// @Failure		400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/task/{id}/move [post]
+func (h *taskController) Move(c *gin.Context) {
+	var input request.TaskMoveRequest
+	var id request.TaskGetRequest
+	err := c.ShouldBindUri(&id)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	inputErr := c.ShouldBind(&input)
+	if inputErr != nil {
+		c.Error(apperr.Wrap(apperr.Validation, inputErr))
+		return
+	}
+
+	task, taskErr := h.taskEntity.GetTask(id.Id, GetAuthUserID(c))
+	if taskErr != nil {
+		c.Error(taskErr)
+		return
+	}
+
+	status, statusErr := h.workflowEntity.GetWorkflowStatus(input.StatusID, GetAuthUserID(c))
+	if statusErr != nil {
+		c.Error(statusErr)
+		return
+	}
+	if status.ID == 0 {
+		c.Error(services.ErrWorkflowStatusNotFound)
+		return
+	}
+
+	moveTask, moveTaskErr := h.taskService.MoveTask(task, status, input.Position, GetTimezone(c))
+	if moveTaskErr != nil {
+		c.Error(moveTaskErr)
 		return
 	}
 
-	response := responses.SuccessResponse(http.StatusOK, "Delete Success", nil)
+	h.taskAttachmentService.SignTaskAttachments(moveTask.Attachments)
+	moveTask.Localize(GetTimezone(c))
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Update Success", moveTask)
 	c.JSON(http.StatusOK, response)
 	return
 }
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"go-todolist/entity"
+	"go-todolist/request"
+	"go-todolist/services"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/responses"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TaskDependencyController interface {
+	Create(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type taskDependencyController struct {
+	taskDependencyEntity entity.TaskDependencyEntity
+	taskEntity           entity.TaskEntity
+	// sign the download links of the attachments
+	taskAttachmentService services.TaskAttachmentService
+}
+
+func NewTaskDependencyController(taskDependencyEntity entity.TaskDependencyEntity, taskEntity entity.TaskEntity, taskAttachmentService services.TaskAttachmentService) TaskDependencyController {
+	return &taskDependencyController{
+		taskDependencyEntity:  taskDependencyEntity,
+		taskEntity:            taskEntity,
+		taskAttachmentService: taskAttachmentService,
+	}
+}
+
+// @Summary		"Block a task by another task"
+// @Description	"The task is blocked (is_blocked) until the blocking task is complete, a link creating a cycle is refused"
+// @Tags		"Task"
+// @Version		1.0
+// @Accept		application/x-www-form-urlencoded
+// @Produce		application/json
+// @Param		Authorization	header		string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param		id				path		integer	true	"Task ID"										minimum(1)
+// @Param		blocked_by_id	formData	integer	true	"Blocking task ID"								minimum(1)
+// @Success		201 object responses.Response{errors=string,data=model.Task} "Create Success"
+// @Failure		400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/task/{id}/blocked-by [post]
+func (h *taskDependencyController) Create(c *gin.Context) {
+	var input request.TaskDependencyCreateRequest
+	var id request.TaskGetRequest
+	err := c.ShouldBindUri(&id)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	inputErr := c.ShouldBind(&input)
+	if inputErr != nil {
+		c.Error(apperr.Wrap(apperr.Validation, inputErr))
+		return
+	}
+
+	task, taskErr := h.taskEntity.GetTask(id.Id, GetAuthUserID(c))
+	if taskErr != nil {
+		c.Error(taskErr)
+		return
+	}
+
+	blocker, blockerErr := h.taskEntity.GetTask(input.BlockedByID, GetAuthUserID(c))
+	if blockerErr == apperr.ErrRecordNotFound {
+		blockerErr = entity.ErrBlockingTaskNotFound
+	}
+	if blockerErr != nil {
+		c.Error(blockerErr)
+		return
+	}
+
+	dependencyErr := h.taskDependencyEntity.CreateTaskDependency(task.ID, blocker.ID, task.UserID)
+	if dependencyErr != nil {
+		c.Error(dependencyErr)
+		return
+	}
+
+	task, taskErr = h.taskEntity.GetTask(task.ID, GetAuthUserID(c))
+	if taskErr != nil {
+		c.Error(taskErr)
+		return
+	}
+
+	h.taskAttachmentService.SignTaskAttachments(task.Attachments)
+	task.Localize(GetTimezone(c))
+	response := responses.SuccessResponse(GetLocale(c), http.StatusCreated, "Create Success", task)
+	c.JSON(http.StatusCreated, response)
+	return
+}
+
+// @Summary "Unblock a task from another task"
+// @Tags	"Task"
+// @Version 1.0
+// @Produce application/json
+// @Param	Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	id				path	integer	true	"Task ID"										minimum(1)
+// @Param	blocked_by_id	path	integer	true	"Blocking task ID"								minimum(1)
+// @Success 200 object responses.Response{errors=string,data=string} "Delete Success"
+// @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/task/{id}/blocked-by/{blocked_by_id} [delete]
+func (h *taskDependencyController) Delete(c *gin.Context) {
+	var input request.TaskDependencyGetRequest
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	task, taskErr := h.taskEntity.GetTask(input.Id, GetAuthUserID(c))
+	if taskErr != nil {
+		c.Error(taskErr)
+		return
+	}
+
+	deleted, deleteErr := h.taskDependencyEntity.DeleteTaskDependency(task.ID, input.BlockedByID)
+	if deleteErr != nil {
+		c.Error(deleteErr)
+		return
+	}
+	if !deleted {
+		c.Error(apperr.ErrRecordNotFound)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Delete Success", nil)
+	c.JSON(http.StatusOK, response)
+	return
+}
@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"crypto/subtle"
+	"go-todolist/entity"
+	"go-todolist/services"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/log"
+	"go-todolist/utils/responses"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TelegramController interface {
+	CreateLink(c *gin.Context)
+	GetLink(c *gin.Context)
+	Unlink(c *gin.Context)
+	Webhook(c *gin.Context)
+}
+
+type telegramController struct {
+	telegramService services.TelegramService
+	userEntity      entity.UserEntity
+}
+
+func NewTelegramController(telegramService services.TelegramService, userEntity entity.UserEntity) TelegramController {
+	return &telegramController{
+		telegramService: telegramService,
+		userEntity:      userEntity,
+	}
+}
+
+// @Summary		"Create Telegram link code"
+// @Description	"Send /start {code} to the bot (or open start_url) before the code expires to link the Telegram chat"
+// @Tags		"Telegram"
+// @Version		1.0
+// @Produce		application/json
+// @Param		Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Success		201 object responses.Response{errors=string,data=model.TelegramLink} "Create Success"
+// @Failure		500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/telegram/link [post]
+func (h *telegramController) CreateLink(c *gin.Context) {
+	link, err := h.telegramService.CreateLinkCode(uint64(GetAuthUserID(c)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusCreated, "Create Success", link)
+	c.JSON(http.StatusCreated, response)
+	return
+}
+
+// @Summary	"Get Telegram link status"
+// @Tags	"Telegram"
+// @Version	1.0
+// @Produce	application/json
+// @Param	Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Success	200 object responses.Response{errors=string,data=model.User} "Successfully get telegram link status"
+// @Failure	404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/telegram/link [get]
+func (h *telegramController) GetLink(c *gin.Context) {
+	user := h.userEntity.FindByID(uint64(GetAuthUserID(c)))
+	if user.ID == 0 {
+		c.Error(apperr.ErrRecordNotFound)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Successfully get telegram link status", user)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary	"Unlink Telegram"
+// @Tags	"Telegram"
+// @Version	1.0
+// @Produce	application/json
+// @Param	Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Success	200 object responses.Response{errors=string,data=string} "Unlink Success"
+// @Failure	500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/telegram/link [delete]
+func (h *telegramController) Unlink(c *gin.Context) {
+	err := h.telegramService.Unlink(uint64(GetAuthUserID(c)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Unlink Success", nil)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary		"Telegram bot webhook"
+// @Description	"Receives the bot updates, /start {code} links the chat. Set the same secret_token as TELEGRAM_WEBHOOK_SECRET on setWebhook, the updates are refused without it"
+// @Tags		"Telegram"
+// @Version		1.0
+// @Accept		application/json
+// @Produce		application/json
+// @Param		X-Telegram-Bot-Api-Secret-Token	header	string	true	"Webhook secret token"
+// @Success		200 object responses.Response{errors=string,data=string} "Update processed"
+// @Failure		401 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/telegram/webhook [post]
+func (h *telegramController) Webhook(c *gin.Context) {
+	// Without a secret anyone could post updates and link a chat to a pending code
+	secret := os.Getenv("TELEGRAM_WEBHOOK_SECRET")
+	if secret == "" || subtle.ConstantTimeCompare([]byte(c.GetHeader("X-Telegram-Bot-Api-Secret-Token")), []byte(secret)) != 1 {
+		c.Error(services.ErrTelegramWebhookSecretInvalid)
+		return
+	}
+
+	update, err := ioutil.ReadAll(c.Request.Body)
+	if err != nil {
+		c.Error(apperr.Wrap(apperr.Validation, err))
+		return
+	}
+
+	// Always answer 200, otherwise Telegram keeps redelivering the update
+	handleErr := h.telegramService.HandleUpdate(update)
+	if handleErr != nil {
+		log.Error("Failed to handle the telegram update : " + handleErr.Error())
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Update processed", nil)
+	c.JSON(http.StatusOK, response)
+	return
+}
@@ -0,0 +1,271 @@
+package controller
+
+import (
+	"go-todolist/entity"
+	"go-todolist/request"
+	"go-todolist/services"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/responses"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashController interface {
+	GetTasks(c *gin.Context)
+	GetCategories(c *gin.Context)
+	RestoreTask(c *gin.Context)
+	PurgeTask(c *gin.Context)
+	RestoreCategory(c *gin.Context)
+	PurgeCategory(c *gin.Context)
+	Empty(c *gin.Context)
+}
+
+type trashController struct {
+	trashService   services.TrashService
+	taskEntity     entity.TaskEntity
+	categoryEntity entity.CategoryEntity
+	// sign the download links of the attachments
+	taskAttachmentService services.TaskAttachmentService
+}
+
+func NewTrashController(trashService services.TrashService, taskEntity entity.TaskEntity, categoryEntity entity.CategoryEntity, taskAttachmentService services.TaskAttachmentService) TrashController {
+	return &trashController{
+		trashService:          trashService,
+		taskEntity:            taskEntity,
+		categoryEntity:        categoryEntity,
+		taskAttachmentService: taskAttachmentService,
+	}
+}
+
+// @Summary		"Deleted tasks"
+// @Description	"The tasks in the trash, the most recently deleted first. They are purged after TRASH_RETENTION"
+// @Tags		"Trash"
+// @Version		1.0
+// @Produce		application/json
+// @Param		Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param		page			query	integer	true	"Page"											minimum(1) default(1)
+// @Param		limit			query	integer	true	"Limit"											minimum(2) default(5)
+// @Success		200 object responses.PageResponse{errors=string,data=[]model.Task} "Successfully get trashed task list"
+// @Failure		400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/trash/tasks [get]
+func (h *trashController) GetTasks(c *gin.Context) {
+	var input request.TrashListRequest
+	err := c.ShouldBind(&input)
+	if err != nil {
+		c.Error(apperr.Wrap(apperr.Validation, err))
+		return
+	}
+
+	tasks := h.taskEntity.GetTrashedTaskList(GetAuthUserID(c), input.Page, input.Limit)
+	for i := range tasks.Data {
+		h.taskAttachmentService.SignTaskAttachments(tasks.Data[i].Attachments)
+		tasks.Data[i].Localize(GetTimezone(c))
+	}
+	response := responses.SuccessPageResponse(GetLocale(c), http.StatusOK, "Successfully get trashed task list", tasks.CurrentPage, tasks.PageLimit, tasks.Total, tasks.Pages, tasks.Data)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary		"Deleted categories"
+// @Description	"The categories in the trash, the most recently deleted first. They are purged after TRASH_RETENTION"
+// @Tags		"Trash"
+// @Version		1.0
+// @Produce		application/json
+// @Param		Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param		page			query	integer	true	"Page"											minimum(1) default(1)
+// @Param		limit			query	integer	true	"Limit"											minimum(2) default(5)
+// @Success		200 object responses.PageResponse{errors=string,data=[]model.Category} "Successfully get trashed category list"
+// @Failure		400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/trash/categories [get]
+func (h *trashController) GetCategories(c *gin.Context) {
+	var input request.TrashListRequest
+	err := c.ShouldBind(&input)
+	if err != nil {
+		c.Error(apperr.Wrap(apperr.Validation, err))
+		return
+	}
+
+	categories := h.categoryEntity.GetTrashedCategoryList(GetAuthUserID(c), input.Page, input.Limit)
+	response := responses.SuccessPageResponse(GetLocale(c), http.StatusOK, "Successfully get trashed category list", categories.CurrentPage, categories.PageLimit, categories.Total, categories.Pages, categories.Data)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary		"Restore a deleted task"
+// @Description	"If the category of the task is in the trash, it is restored as well"
+// @Tags		"Trash"
+// @Version		1.0
+// @Produce		application/json
+// @Param		Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param		id				path	integer	true	"Task ID"										minimum(1)
+// @Success		200 object responses.Response{errors=string,data=model.Task} "Restore Success"
+// @Failure		400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		409 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/trash/tasks/{id}/restore [post]
+func (h *trashController) RestoreTask(c *gin.Context) {
+	var input request.TrashGetRequest
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	task, taskErr := h.taskEntity.GetTrashedTask(input.Id, GetAuthUserID(c))
+	if taskErr != nil {
+		c.Error(taskErr)
+		return
+	}
+
+	restoreErr := h.taskEntity.RestoreTask(task, services.GetTaskTitleUnique())
+	if restoreErr != nil {
+		c.Error(restoreErr)
+		return
+	}
+
+	restored, _ := h.taskEntity.GetTask(task.ID, task.UserID)
+	h.taskAttachmentService.SignTaskAttachments(restored.Attachments)
+	restored.Localize(GetTimezone(c))
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Restore Success", restored)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary		"Delete a task permanently"
+// @Description	"Only a task in the trash can be purged, its attachments are removed as well"
+// @Tags		"Trash"
+// @Version		1.0
+// @Produce		application/json
+// @Param		Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param		id				path	integer	true	"Task ID"										minimum(1)
+// @Success		200 object responses.Response{errors=string,data=string} "Purge Success"
+// @Failure		400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/trash/tasks/{id} [delete]
+func (h *trashController) PurgeTask(c *gin.Context) {
+	var input request.TrashGetRequest
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	task, taskErr := h.taskEntity.GetTrashedTask(input.Id, GetAuthUserID(c))
+	if taskErr != nil {
+		c.Error(taskErr)
+		return
+	}
+
+	purgeErr := h.taskEntity.PurgeTask(task.ID, task.UserID)
+	if purgeErr != nil {
+		c.Error(purgeErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Purge Success", nil)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary		"Restore a deleted category"
+// @Description	"The tasks deleted along with the category are restored as well"
+// @Tags		"Trash"
+// @Version		1.0
+// @Produce		application/json
+// @Param		Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param		id				path	integer	true	"Category ID"									minimum(1)
+// @Success		200 object responses.Response{errors=string,data=model.Category} "Restore Success"
+// @Failure		400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		409 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/trash/categories/{id}/restore [post]
+func (h *trashController) RestoreCategory(c *gin.Context) {
+	var input request.TrashGetRequest
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	category, categoryErr := h.categoryEntity.GetTrashedCategory(input.Id, GetAuthUserID(c))
+	if categoryErr != nil {
+		c.Error(categoryErr)
+		return
+	}
+
+	restoreErr := h.categoryEntity.RestoreCategory(category, services.GetTaskTitleUnique())
+	if restoreErr != nil {
+		c.Error(restoreErr)
+		return
+	}
+
+	restored, _ := h.categoryEntity.GetCategory(category.ID, GetAuthUserID(c))
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Restore Success", restored)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary		"Delete a category permanently"
+// @Description	"Only a category in the trash can be purged, the tasks deleted along with it are removed as well"
+// @Tags		"Trash"
+// @Version		1.0
+// @Produce		application/json
+// @Param		Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param		id				path	integer	true	"Category ID"									minimum(1)
+// @Success		200 object responses.Response{errors=string,data=string} "Purge Success"
+// @Failure		400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/trash/categories/{id} [delete]
+func (h *trashController) PurgeCategory(c *gin.Context) {
+	var input request.TrashGetRequest
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	category, categoryErr := h.categoryEntity.GetTrashedCategory(input.Id, GetAuthUserID(c))
+	if categoryErr != nil {
+		c.Error(categoryErr)
+		return
+	}
+
+	purgeErr := h.categoryEntity.PurgeCategory(category.ID, GetAuthUserID(c))
+	if purgeErr != nil {
+		c.Error(purgeErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Purge Success", nil)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary		"Empty the trash"
+// @Description	"Deletes permanently every task and category in the trash"
+// @Tags		"Trash"
+// @Version		1.0
+// @Produce		application/json
+// @Param		Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Success		200 object responses.Response{errors=string,data=string} "Purge Success"
+// @Failure		500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/trash [delete]
+func (h *trashController) Empty(c *gin.Context) {
+	tasks, categories, err := h.trashService.EmptyTrash(GetAuthUserID(c))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Purge Success", gin.H{
+		"tasks":      tasks,
+		"categories": categories,
+	})
+	c.JSON(http.StatusOK, response)
+	return
+}
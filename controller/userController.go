@@ -4,6 +4,9 @@ import (
 	"go-todolist/model"
 	"go-todolist/request"
 	"go-todolist/services"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/civilDatetime"
+	"go-todolist/utils/i18n"
 	"go-todolist/utils/responses"
 	"net/http"
 	"strings"
@@ -18,6 +21,11 @@ type UserController interface {
 	Register(c *gin.Context)
 	RefreshToken(c *gin.Context)
 	Logout(c *gin.Context)
+	Sessions(c *gin.Context)
+	RevokeSession(c *gin.Context)
+	RevokeOtherSessions(c *gin.Context)
+	Profile(c *gin.Context)
+	UpdateProfile(c *gin.Context)
 }
 
 // User Controller struct to implement UserController interface
@@ -58,6 +66,57 @@ func ParseToken(c *gin.Context) string {
 	return authHeader
 }
 
+// The gin context keys set by the JWT, locale and user preferences middlewares
+const (
+	AuthUserIDKey    = "user_id"
+	AuthSessionIDKey = "session_id"
+	LocaleKey        = "locale"
+	TimezoneKey      = "timezone"
+)
+
+// GetAuthUserID is a shared method for get the authenticated user ID (0 if absent)
+func GetAuthUserID(c *gin.Context) int64 {
+	return c.GetInt64(AuthUserIDKey)
+}
+
+// GetAuthSessionID is a shared method for get the current session ID (jti)
+func GetAuthSessionID(c *gin.Context) string {
+	return c.GetString(AuthSessionIDKey)
+}
+
+// GetLocale is a shared method for get the locale of the response messages, the default locale if none is negotiated
+func GetLocale(c *gin.Context) string {
+	if locale := c.GetString(LocaleKey); locale != "" {
+		return locale
+	}
+
+	return i18n.GetDefaultLocale()
+}
+
+// SetLocale is a shared method for set the locale of the response messages
+func SetLocale(c *gin.Context, locale string) {
+	c.Set(LocaleKey, locale)
+	c.Header("Content-Language", locale)
+}
+
+// GetTimezone is a shared method for get the location of the datetimes of the user, the default timezone if the user has none
+func GetTimezone(c *gin.Context) *time.Location {
+	if loc, ok := c.Get(TimezoneKey); ok {
+		return loc.(*time.Location)
+	}
+
+	return civilDatetime.DefaultLocation()
+}
+
+// NewSession is a shared method for collect the device information of a new session
+func NewSession(c *gin.Context, device string) model.Session {
+	return model.Session{
+		Device:    device,
+		IP:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
 // Login is a function for user login
 // @Summary "User Login"
 // @Tags	"Auth"
@@ -72,37 +131,32 @@ func ParseToken(c *gin.Context) string {
 func (h *userController) Login(c *gin.Context) {
 	// create new instance of LoginRequest
 	var input request.LoginRequest
-	jwtTTL := services.GetTokenTTL()
 
 	// bind the input with the request body
 	err := c.ShouldBindJSON(&input)
 	// Check if there is any error in binding
 	if err != nil {
-		response := responses.ErrorsResponse(http.StatusBadRequest, "Failed to process request", err.Error(), nil)
-		// response := responses.ErrorsResponse(http.StatusBadRequest, "Failed to process request", err.Error(), responses.EmptyObject{})
-		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		c.Error(apperr.Wrap(apperr.Validation, err))
 		return
 	}
 
 	// Check if the email and password is valid
-	loginResult := h.userService.VerifyCredential(input.Email, input.Password)
-	if v, ok := loginResult.(model.User); ok {
-		generatedToken := h.jwtService.GenerateToken(v.ID, time.Now().Add(time.Duration(jwtTTL)*time.Second))
-		if len(generatedToken) < 1 {
-			response := responses.ErrorsResponseByCode(http.StatusInternalServerError, "Failed to process request", responses.SignatureFailed, nil)
-			c.AbortWithStatusJSON(http.StatusInternalServerError, response)
-			return
-		}
-
-		v.Token = generatedToken
-		response := responses.SuccessResponse(http.StatusOK, "Login successfully", v)
-		c.JSON(http.StatusOK, response)
+	user, loginErr := h.userService.VerifyCredential(input.Email, input.Password)
+	if loginErr != nil {
+		c.Error(loginErr)
+		return
+	}
+
+	generatedToken := h.jwtService.GenerateTokenPair(user.ID, NewSession(c, input.Device))
+	if len(generatedToken.Token) < 1 {
+		c.Error(services.ErrSignatureFailed)
 		return
 	}
 
-	// If the email and password is not valid
-	response := responses.ErrorsResponseByCode(http.StatusUnauthorized, "Failed to process request", responses.InvalidCredential, nil)
-	c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+	user.Token = generatedToken.Token
+	user.RefreshToken = generatedToken.RefreshToken
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Login successfully", user)
+	c.JSON(http.StatusOK, response)
 	return
 }
 
@@ -114,6 +168,8 @@ func (h *userController) Login(c *gin.Context) {
 // @Param	* body request.RegisterRequest true "User Register"
 // @Success 201 object responses.Response{errors=string,data=string} "Register Success"
 // @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 409 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Router	/auth/register [post]
 func (h *userController) Register(c *gin.Context) {
 	// create new instance of RegisterRequest
@@ -123,55 +179,56 @@ func (h *userController) Register(c *gin.Context) {
 	err := c.ShouldBind(&input)
 	// Check if there is any error in binding
 	if err != nil {
-		response := responses.ErrorsResponse(http.StatusBadRequest, "Failed to process request", err.Error(), nil)
-		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+		c.Error(apperr.Wrap(apperr.Validation, err))
 		return
 	}
 
 	// if the email is valid and unique in the database then register the user
 	// create new user
-	createdUser := h.userService.CreateUser(input)
+	createdUser, createErr := h.userService.CreateUser(input)
 	// Check if then email exists
-	if createdUser.ID == 0 {
-		response := responses.ErrorsResponseByCode(http.StatusBadRequest, "Failed to process request", responses.EmailAlreadyExists, nil)
-		c.AbortWithStatusJSON(http.StatusBadRequest, response)
+	if createErr != nil {
+		c.Error(createErr)
 		return
 	}
 
 	// response with the user data and token
-	response := responses.SuccessResponse(http.StatusCreated, "Register Success", createdUser)
+	response := responses.SuccessResponse(GetLocale(c), http.StatusCreated, "Register Success", createdUser)
 	// return the response
 	c.JSON(http.StatusCreated, response)
 	return
 }
 
-// RefreshToken is a function for token refresh
+// RefreshToken is a function for rotate the refresh token and get a new access token
 // @Summary "User Refresh Token"
 // @Tags	"Auth"
 // @Version 1.0
 // @Produce application/json
-// @Param	Authorization header string true "example:Bearer token (Bearer+space+token)." default(Bearer )
-// @Success 200 object responses.Response{errors=string,data=string} "Refresh token successfully"
+// @Param	* body request.RefreshTokenRequest true "User Refresh Token"
+// @Success 200 object responses.Response{errors=string,data=model.Token} "Refresh token successfully"
+// @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Failure 401 object responses.Response{errors=string,data=string} "Failed to process request"
 // @Router	/auth/refresh [post]
 func (h *userController) RefreshToken(c *gin.Context) {
-	var refreshToken model.Token
-	authHeader := ParseToken(c)
-	token := h.jwtService.RefreshToken(authHeader)
-	if len(token) < 1 {
-		response := responses.ErrorsResponseByCode(http.StatusUnauthorized, "Failed to process request", responses.TokenContainsAnInvalidNumberOfSegments, nil)
-		c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+	var input request.RefreshTokenRequest
+	err := c.ShouldBind(&input)
+	if err != nil {
+		c.Error(apperr.Wrap(apperr.Validation, err))
 		return
 	}
 
-	// set up return format
-	refreshToken.Token = token
-	response := responses.SuccessResponse(http.StatusOK, "Refresh token successfully", refreshToken)
+	refreshToken, refreshErr := h.jwtService.RefreshToken(input.RefreshToken)
+	if refreshErr != nil {
+		c.Error(refreshErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Refresh token successfully", refreshToken)
 	c.JSON(http.StatusOK, response)
 	return
 }
 
-// Logout is a function for user logout
+// Logout is a function for user logout (only the current session)
 // @Summary "User Logout"
 // @Tags	"Auth"
 // @Version 1.0
@@ -184,12 +241,134 @@ func (h *userController) Logout(c *gin.Context) {
 	authHeader := ParseToken(c)
 	logout := h.jwtService.Logout(authHeader)
 	if logout == true {
-		response := responses.SuccessResponse(http.StatusOK, "Successfully logged out", nil)
+		response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Successfully logged out", nil)
 		c.JSON(http.StatusOK, response)
 	} else {
-		response := responses.ErrorsResponseByCode(http.StatusUnauthorized, "Failed to process request", responses.FailedToLogout, nil)
-		c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+		c.Error(apperr.New(apperr.Unauthorized, responses.FailedToLogout))
+	}
+
+	return
+}
+
+// Sessions is a function for list the active sessions of the user
+// @Summary "User Sessions"
+// @Tags	"Auth"
+// @Version 1.0
+// @Produce application/json
+// @Param	Authorization header string true "example:Bearer token (Bearer+space+token)." default(Bearer )
+// @Success 200 object responses.Response{errors=string,data=[]model.Session} "Successfully get sessions"
+// @Failure 401 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/auth/sessions [get]
+func (h *userController) Sessions(c *gin.Context) {
+	sessions := h.jwtService.ListSessions(uint64(GetAuthUserID(c)), GetAuthSessionID(c))
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Successfully get sessions", sessions)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// RevokeSession is a function for revoke a single session of the user
+// @Summary "User Revoke Session"
+// @Tags	"Auth"
+// @Version 1.0
+// @Produce application/json
+// @Param	Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	id				path	string	true	"Session ID"
+// @Success 200 object responses.Response{errors=string,data=string} "Successfully revoked session"
+// @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/auth/sessions/{id} [delete]
+func (h *userController) RevokeSession(c *gin.Context) {
+	var input request.SessionRequest
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
 	}
 
+	revoked := h.jwtService.RevokeSession(uint64(GetAuthUserID(c)), input.Id)
+	if !revoked {
+		c.Error(apperr.ErrRecordNotFound)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Successfully revoked session", nil)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// RevokeOtherSessions is a function for revoke every session of the user except the current one
+// @Summary "User Revoke Other Sessions"
+// @Tags	"Auth"
+// @Version 1.0
+// @Produce application/json
+// @Param	Authorization header string true "example:Bearer token (Bearer+space+token)." default(Bearer )
+// @Success 200 object responses.Response{errors=string,data=integer} "Successfully revoked other sessions"
+// @Failure 401 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/auth/sessions [delete]
+func (h *userController) RevokeOtherSessions(c *gin.Context) {
+	revoked := h.jwtService.RevokeOtherSessions(uint64(GetAuthUserID(c)), GetAuthSessionID(c))
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Successfully revoked other sessions", revoked)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// Profile is a function for get the profile settings of the user
+// @Summary "User Profile"
+// @Tags	"Auth"
+// @Version 1.0
+// @Produce application/json
+// @Param	Authorization header string true "example:Bearer token (Bearer+space+token)." default(Bearer )
+// @Success 200 object responses.Response{errors=string,data=model.User} "Successfully get profile"
+// @Failure 401 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/auth/profile [get]
+func (h *userController) Profile(c *gin.Context) {
+	user, err := h.userService.GetProfile(uint64(GetAuthUserID(c)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Successfully get profile", user)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// UpdateProfile is a function for update the profile settings of the user, e.g. the preferred locale of the messages and the timezone
+// @Summary		"User Update Profile"
+// @Description	"The locale (en, zh-TW) is used for the messages instead of Accept-Language, the IANA timezone (e.g. Asia/Taipei) for the datetimes instead of DEFAULT_TIMEZONE. An empty one clears it"
+// @Tags		"Auth"
+// @Version		1.0
+// @Accept		application/x-www-form-urlencoded
+// @Produce		application/json
+// @Param		Authorization	header		string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param		locale			formData	string	false	"Preferred locale"								maxLength(10)
+// @Param		timezone		formData	string	false	"IANA timezone"									maxLength(64)
+// @Success		200 object responses.Response{errors=string,data=model.User} "Update Success"
+// @Failure		400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		401 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/auth/profile [patch]
+func (h *userController) UpdateProfile(c *gin.Context) {
+	var input request.ProfileUpdateRequest
+	err := c.ShouldBind(&input)
+	if err != nil {
+		c.Error(apperr.Wrap(apperr.Validation, err))
+		return
+	}
+
+	user, err := h.userService.UpdateProfile(uint64(GetAuthUserID(c)), input)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	// The response is already in the new locale
+	if input.Locale != nil {
+		SetLocale(c, i18n.Resolve(user.Locale, c.GetHeader("Accept-Language")))
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Update Success", user)
+	c.JSON(http.StatusOK, response)
 	return
 }
@@ -0,0 +1,367 @@
+package controller
+
+import (
+	"go-todolist/entity"
+	"go-todolist/model"
+	"go-todolist/request"
+	"go-todolist/services"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/responses"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type WorkflowController interface {
+	GetByList(c *gin.Context)
+	Create(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	Reorder(c *gin.Context)
+	SetTransitions(c *gin.Context)
+}
+
+type workflowController struct {
+	workflowEntity entity.WorkflowEntity
+}
+
+func NewWorkflowController(workflowEntity entity.WorkflowEntity) WorkflowController {
+	return &workflowController{
+		workflowEntity: workflowEntity,
+	}
+}
+
+// getStatuses returns the user's statuses, it aborts the request if they can not be read
+func (h *workflowController) getStatuses(c *gin.Context) ([]model.WorkflowStatus, bool) {
+	statuses, statusesErr := h.workflowEntity.GetWorkflowStatusList(GetAuthUserID(c))
+	if statusesErr != nil {
+		c.Error(statusesErr)
+		return statuses, false
+	}
+
+	return statuses, true
+}
+
+// findStatus returns the status of the id among the statuses
+func findStatus(statuses []model.WorkflowStatus, id int64) (model.WorkflowStatus, bool) {
+	for _, status := range statuses {
+		if status.ID == id {
+			return status, true
+		}
+	}
+
+	return model.WorkflowStatus{}, false
+}
+
+// keepsDoneAndNotDone reports whether the statuses still have a status not done and a done one without the status of the id,
+// with is_done of the status changed to done (nil when the status is removed)
+func keepsDoneAndNotDone(statuses []model.WorkflowStatus, id int64, done *bool) bool {
+	hasDone, hasNotDone := false, false
+	for _, status := range statuses {
+		isDone := status.IsDone
+		if status.ID == id {
+			if done == nil {
+				continue
+			}
+			isDone = *done
+		}
+		hasDone = hasDone || isDone
+		hasNotDone = hasNotDone || !isDone
+	}
+
+	return hasDone && hasNotDone
+}
+
+// @Summary		"Workflow statuses (kanban columns)"
+// @Description	"The statuses of the user in order, todo, doing and done by default"
+// @Tags		"Workflow"
+// @Version		1.0
+// @Produce		application/json
+// @Param		Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Success		200 object responses.Response{errors=string,data=[]model.WorkflowStatus} "Successfully get workflow status list"
+// @Failure		500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/workflow/statuses [get]
+func (h *workflowController) GetByList(c *gin.Context) {
+	statuses, ok := h.getStatuses(c)
+	if !ok {
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Successfully get workflow status list", statuses)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary "Create a workflow status"
+// @Tags	"Workflow"
+// @Version 1.0
+// @Accept	application/x-www-form-urlencoded
+// @Produce application/json
+// @Param	Authorization	header		string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param	name			formData	string	true	"Status Name"									maxLength(50)
+// @Param	is_done			formData	boolean	false	"The tasks in the status are complete"			default(false)
+// @Success 201 object responses.Response{errors=string,data=model.WorkflowStatus} "Create Success"
+// @Failure 400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 409 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure 500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router	/workflow/statuses [post]
+func (h *workflowController) Create(c *gin.Context) {
+	var input request.WorkflowStatusCreateRequest
+	err := c.ShouldBind(&input)
+	if err != nil {
+		c.Error(apperr.Wrap(apperr.Validation, err))
+		return
+	}
+
+	// The default statuses come first
+	if _, ok := h.getStatuses(c); !ok {
+		return
+	}
+
+	status, statusErr := h.workflowEntity.CreateWorkflowStatus(model.WorkflowStatus{UserID: GetAuthUserID(c), Name: input.Name, IsDone: input.IsDone})
+	if statusErr != nil {
+		c.Error(statusErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusCreated, "Create Success", status)
+	c.JSON(http.StatusCreated, response)
+	return
+}
+
+// @Summary		"Update a workflow status"
+// @Description	"Changing is_done updates is_complete of the tasks in the status"
+// @Tags		"Workflow"
+// @Version		1.0
+// @Accept		application/x-www-form-urlencoded
+// @Produce		application/json
+// @Param		Authorization	header		string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param		id				path		integer	true	"Status ID"										minimum(1)
+// @Param		name			formData	string	false	"Status Name"									minLength(1) maxLength(50)
+// @Param		is_done			formData	boolean	false	"The tasks in the status are complete"
+// @Success		200 object responses.Response{errors=string,data=model.WorkflowStatus} "Update Success"
+// @Failure		400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		409 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/workflow/statuses/{id} [patch]
+func (h *workflowController) Update(c *gin.Context) {
+	var input request.WorkflowStatusUpdateRequest
+	var id request.WorkflowStatusGetRequest
+	err := c.ShouldBindUri(&id)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	inputErr := c.ShouldBind(&input)
+	if inputErr != nil {
+		c.Error(apperr.Wrap(apperr.Validation, inputErr))
+		return
+	}
+
+	statuses, ok := h.getStatuses(c)
+	if !ok {
+		return
+	}
+	status, found := findStatus(statuses, id.Id)
+	if !found {
+		c.Error(apperr.ErrRecordNotFound)
+		return
+	}
+
+	values := map[string]interface{}{}
+	if input.Name != nil {
+		values["name"] = *input.Name
+	}
+	if input.IsDone != nil && *input.IsDone != status.IsDone {
+		if !keepsDoneAndNotDone(statuses, status.ID, input.IsDone) {
+			c.Error(entity.ErrWorkflowStatusRequired)
+			return
+		}
+		values["is_done"] = *input.IsDone
+	}
+	if len(values) > 0 {
+		var statusErr error
+		status, statusErr = h.workflowEntity.UpdateWorkflowStatus(status.ID, GetAuthUserID(c), values)
+		if statusErr != nil {
+			c.Error(statusErr)
+			return
+		}
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Update Success", status)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary		"Delete a workflow status"
+// @Description	"Only a status without tasks (including the trash) can be deleted, a status not done and a done status are always kept"
+// @Tags		"Workflow"
+// @Version		1.0
+// @Produce		application/json
+// @Param		Authorization	header	string	true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param		id				path	integer	true	"Status ID"										minimum(1)
+// @Success		200 object responses.Response{errors=string,data=string} "Delete Success"
+// @Failure		400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/workflow/statuses/{id} [delete]
+func (h *workflowController) Delete(c *gin.Context) {
+	var input request.WorkflowStatusGetRequest
+	err := c.ShouldBindUri(&input)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	statuses, ok := h.getStatuses(c)
+	if !ok {
+		return
+	}
+	status, found := findStatus(statuses, input.Id)
+	if !found {
+		c.Error(apperr.ErrRecordNotFound)
+		return
+	}
+	if !keepsDoneAndNotDone(statuses, status.ID, nil) {
+		c.Error(entity.ErrWorkflowStatusRequired)
+		return
+	}
+
+	count, countErr := h.workflowEntity.CountWorkflowStatusTasks(status.ID)
+	if countErr != nil {
+		c.Error(countErr)
+		return
+	}
+	if count > 0 {
+		c.Error(entity.ErrWorkflowStatusInUse)
+		return
+	}
+
+	deleteErr := h.workflowEntity.DeleteWorkflowStatus(status.ID, GetAuthUserID(c))
+	if deleteErr != nil {
+		c.Error(deleteErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Delete Success", nil)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary		"Reorder the workflow statuses"
+// @Description	"status_ids must contain every status of the user exactly once, in the new order"
+// @Tags		"Workflow"
+// @Version		1.0
+// @Accept		application/json
+// @Produce		application/json
+// @Param		Authorization	header	string									true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param		*				body	request.WorkflowStatusReorderRequest	true	"Status IDs in the new order"
+// @Success		200 object responses.Response{errors=string,data=[]model.WorkflowStatus} "Update Success"
+// @Failure		400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/workflow/statuses/order [put]
+func (h *workflowController) Reorder(c *gin.Context) {
+	var input request.WorkflowStatusReorderRequest
+	err := c.ShouldBind(&input)
+	if err != nil {
+		c.Error(apperr.Wrap(apperr.Validation, err))
+		return
+	}
+
+	statuses, ok := h.getStatuses(c)
+	if !ok {
+		return
+	}
+
+	// The new order must be a permutation of the current statuses
+	current := map[int64]bool{}
+	for _, status := range statuses {
+		current[status.ID] = true
+	}
+	valid := len(input.StatusIDs) == len(current)
+	for _, statusID := range input.StatusIDs {
+		if !current[statusID] {
+			valid = false
+			break
+		}
+		delete(current, statusID)
+	}
+	if !valid {
+		c.Error(entity.ErrWorkflowOrderInvalid)
+		return
+	}
+
+	statuses, statusesErr := h.workflowEntity.ReorderWorkflowStatuses(GetAuthUserID(c), input.StatusIDs)
+	if statusesErr != nil {
+		c.Error(statusesErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Update Success", statuses)
+	c.JSON(http.StatusOK, response)
+	return
+}
+
+// @Summary		"Set the allowed transitions of a workflow status"
+// @Description	"The tasks of the status can only move to the listed statuses, an empty list allows any status"
+// @Tags		"Workflow"
+// @Version		1.0
+// @Accept		application/json
+// @Produce		application/json
+// @Param		Authorization	header	string								true	"example:Bearer token (Bearer+space+token)."	default(Bearer )
+// @Param		id				path	integer								true	"Status ID"										minimum(1)
+// @Param		*				body	request.WorkflowTransitionsRequest	true	"Statuses the tasks can move to"
+// @Success		200 object responses.Response{errors=string,data=model.WorkflowStatus} "Update Success"
+// @Failure		400 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		404 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Failure		500 object responses.Response{errors=string,data=string} "Failed to process request"
+// @Router		/workflow/statuses/{id}/transitions [put]
+func (h *workflowController) SetTransitions(c *gin.Context) {
+	var input request.WorkflowTransitionsRequest
+	var id request.WorkflowStatusGetRequest
+	err := c.ShouldBindUri(&id)
+	if err != nil {
+		c.Error(apperr.ErrIdInvalid)
+		return
+	}
+
+	inputErr := c.ShouldBind(&input)
+	if inputErr != nil {
+		c.Error(apperr.Wrap(apperr.Validation, inputErr))
+		return
+	}
+
+	statuses, ok := h.getStatuses(c)
+	if !ok {
+		return
+	}
+	status, found := findStatus(statuses, id.Id)
+	if !found {
+		c.Error(apperr.ErrRecordNotFound)
+		return
+	}
+	for _, to := range input.ToStatusIDs {
+		if _, found := findStatus(statuses, to); !found {
+			c.Error(services.ErrWorkflowStatusNotFound)
+			return
+		}
+	}
+
+	transitionsErr := h.workflowEntity.SetWorkflowTransitions(status.ID, input.ToStatusIDs)
+	if transitionsErr != nil {
+		c.Error(transitionsErr)
+		return
+	}
+
+	status, statusErr := h.workflowEntity.GetWorkflowStatus(status.ID, GetAuthUserID(c))
+	if statusErr != nil {
+		c.Error(statusErr)
+		return
+	}
+
+	response := responses.SuccessResponse(GetLocale(c), http.StatusOK, "Update Success", status)
+	c.JSON(http.StatusOK, response)
+	return
+}
@@ -2,7 +2,10 @@ package entity
 
 import (
 	"go-todolist/model"
+	"go-todolist/utils/apperr"
 	"go-todolist/utils/paginator"
+	"go-todolist/utils/responses"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -11,12 +14,32 @@ import (
 type CategoryEntity interface {
 	CreateCategory(category model.Category) (c model.Category, e error)
 	// GetCategoryList(id int, name string) (categories []*model.Category)
-	GetCategoryList(id int64, name string, page int64, limit int64) paginator.Page[model.Category]
-	GetCategory(id int64) (res model.Category, err error)
+	GetCategoryList(id int64, user_id int64, name string, sort clause.OrderBy, page int64, limit int64) paginator.Page[model.Category]
+	GetCategoryListByCursor(id int64, user_id int64, name string, sort clause.OrderBy, cursor string, limit int64, with_total bool) (paginator.CursorPage[model.Category], error)
+	GetCategory(id int64, user_id int64) (res model.Category, err error)
 	UpdateCategory(category model.Category) (c model.Category, e error)
-	DeleteCategory(id int64) (c model.Category, e error)
+	DeleteCategory(id int64, user_id int64) (c model.Category, e error)
+	GetTrashedCategoryList(user_id int64, page int64, limit int64) paginator.Page[model.Category]
+	GetTrashedCategory(id int64, user_id int64) (category model.Category, err error)
+	RestoreCategory(category model.Category, unique string) error
+	PurgeCategory(id int64, user_id int64) error
+	PurgeTrashedCategories(user_id int64) (purged int64, err error)
+	PurgeExpiredCategories(before time.Time) (purged int64, err error)
 }
 
+// CategorySortColumns are the sort fields of the category list
+var CategorySortColumns = paginator.SortColumns{
+	"id":         "categories.id",
+	"name":       "categories.name",
+	"created_at": "categories.created_at",
+	"updated_at": "categories.updated_at",
+}
+
+var (
+	ErrCategoryNameDuplicate  = apperr.New(apperr.Conflict, responses.CategoryNameDuplicate)
+	ErrSystemCategoryReadOnly = apperr.New(apperr.Forbidden, responses.SystemCategoryIsReadOnly)
+)
+
 type categoryConnection struct {
 	connection *gorm.DB
 }
@@ -27,8 +50,12 @@ func NewCategoryEntity(db *gorm.DB) CategoryEntity {
 	}
 }
 
+// CreateCategory creates the category, ErrCategoryNameDuplicate if the user already has the name
 func (db *categoryConnection) CreateCategory(category model.Category) (c model.Category, e error) {
 	create := db.connection.Save(&category)
+	if isDuplicate(create.Error) {
+		return category, ErrCategoryNameDuplicate
+	}
 	if create.Error != nil {
 		return category, create.Error
 	}
@@ -36,7 +63,14 @@ func (db *categoryConnection) CreateCategory(category model.Category) (c model.C
 	return category, nil
 }
 
-func (db *categoryConnection) GetCategoryList(id int64, name string, page int64, limit int64) paginator.Page[model.Category] {
+// categoryVisibleTo limits the query to the user's own categories and the system categories
+func categoryVisibleTo(user_id int64) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(categories.user_id = ? OR categories.user_id IS NULL)", user_id)
+	}
+}
+
+func (db *categoryConnection) GetCategoryList(id int64, user_id int64, name string, sort clause.OrderBy, page int64, limit int64) paginator.Page[model.Category] {
 	// query := db.connection.Model(&categories).Preload(clause.Associations)
 	// if len(name) > 0 {
 	// 	query.Where("name = ?", name)
@@ -47,8 +81,31 @@ func (db *categoryConnection) GetCategoryList(id int64, name string, page int64,
 	// query.Find(&categories)
 	// return categories
 
+	query := db.categoryListQuery(id, user_id, name)
+
+	if len(sort.Columns) > 0 {
+		query.Clauses(sort)
+	}
+
+	p := paginator.Page[model.Category]{CurrentPage: page, PageLimit: limit}
+	p.SelectPages(query)
+
+	return p
+}
+
+// GetCategoryListByCursor gets the categories with the keyset pagination, in the requested sort then by id
+func (db *categoryConnection) GetCategoryListByCursor(id int64, user_id int64, name string, sort clause.OrderBy, cursor string, limit int64, with_total bool) (paginator.CursorPage[model.Category], error) {
+	query := db.categoryListQuery(id, user_id, name)
+
+	p := paginator.CursorPage[model.Category]{PageLimit: limit}
+	err := p.SelectCursor(query, sort, "categories.id", cursor, with_total)
+
+	return p, err
+}
+
+func (db *categoryConnection) categoryListQuery(id int64, user_id int64, name string) *gorm.DB {
 	var categories []*model.Category
-	query := db.connection.Model(&categories).Preload(clause.Associations)
+	query := db.connection.Model(&categories).Preload(clause.Associations).Scopes(categoryVisibleTo(user_id))
 
 	if id > 0 {
 		query.Where("id = ?", id)
@@ -59,23 +116,25 @@ func (db *categoryConnection) GetCategoryList(id int64, name string, page int64,
 		query.Where("name like ?", name+"%")
 	}
 
-	p := paginator.Page[model.Category]{CurrentPage: page, PageLimit: limit}
-	p.SelectPages(query)
-
-	return p
+	return query
 }
 
-func (db *categoryConnection) GetCategory(id int64) (category model.Category, err error) {
-	res := db.connection.First(&category, "id=?", id)
-	if res.Error == nil {
-		return category, nil
+// GetCategory gets the category of the user or the system category, apperr.ErrRecordNotFound (and an empty category) if there is none
+func (db *categoryConnection) GetCategory(id int64, user_id int64) (category model.Category, err error) {
+	res := db.connection.Scopes(categoryVisibleTo(user_id)).First(&category, "id = ?", id)
+	if res.Error == gorm.ErrRecordNotFound {
+		return category, apperr.ErrRecordNotFound
 	}
 
-	return category, err
+	return category, res.Error
 }
 
 func (db *categoryConnection) UpdateCategory(category model.Category) (c model.Category, e error) {
-	update := db.connection.Where("id = ?", category.ID).Updates(&category)
+	// System categories (user_id IS NULL) never match, so they stay read-only
+	update := db.connection.Where("id = ? AND user_id = ?", category.ID, category.UserID).Updates(&category)
+	if isDuplicate(update.Error) {
+		return category, ErrCategoryNameDuplicate
+	}
 	if update.Error != nil {
 		return category, update.Error
 	}
@@ -83,12 +142,111 @@ func (db *categoryConnection) UpdateCategory(category model.Category) (c model.C
 	return category, nil
 }
 
-func (db *categoryConnection) DeleteCategory(id int64) (c model.Category, e error) {
+// DeleteCategory moves the category and its tasks to the trash, they share the same deleted_at
+// so that restoring the category restores the tasks deleted with it, not the ones deleted before
+func (db *categoryConnection) DeleteCategory(id int64, user_id int64) (c model.Category, e error) {
 	category := model.Category{}
-	delete := db.connection.Delete(&category, id)
-	if delete.Error != nil {
-		return category, delete.Error
+	// deleted_at is stored in seconds
+	now := time.Now().Truncate(time.Second)
+	err := db.connection.Transaction(func(tx *gorm.DB) error {
+		deleteTasks := tx.Model(&model.Task{}).Where("category_id = ? AND user_id = ?", id, user_id).Update("deleted_at", now)
+		if deleteTasks.Error != nil {
+			return deleteTasks.Error
+		}
+
+		return tx.Model(&category).Where("id = ? AND user_id = ?", id, user_id).Update("deleted_at", now).Error
+	})
+	if err != nil {
+		return category, err
 	}
 
 	return category, nil
 }
+
+// GetTrashedCategoryList gets the user's categories in the trash, the most recently deleted first
+func (db *categoryConnection) GetTrashedCategoryList(user_id int64, page int64, limit int64) paginator.Page[model.Category] {
+	var categories []*model.Category
+	query := db.connection.Unscoped().Model(&categories).Where("user_id = ? AND deleted_at IS NOT NULL", user_id).Order("deleted_at desc")
+
+	p := paginator.Page[model.Category]{CurrentPage: page, PageLimit: limit}
+	p.SelectPages(query)
+
+	return p
+}
+
+func (db *categoryConnection) GetTrashedCategory(id int64, user_id int64) (category model.Category, err error) {
+	res := db.connection.Unscoped().First(&category, "id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, user_id)
+	if res.Error == gorm.ErrRecordNotFound {
+		return category, apperr.ErrRecordNotFound
+	}
+
+	return category, res.Error
+}
+
+// RestoreCategory takes the category out of the trash, along with the tasks deleted with it.
+// ErrCategoryNameDuplicate or ErrTaskTitleDuplicate if the name or a title was taken in the meantime
+func (db *categoryConnection) RestoreCategory(category model.Category, unique string) error {
+	return db.connection.Transaction(func(tx *gorm.DB) error {
+		taken, err := categoryNameTaken(tx, category)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return ErrCategoryNameDuplicate
+		}
+
+		var ids []int64
+		tasks := tx.Unscoped().Model(&model.Task{}).Where("category_id = ? AND user_id = ? AND deleted_at = ?", category.ID, category.UserID, category.DeletedAt.Time)
+		if err := tasks.Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			taken, err := restoredTitlesTaken(tx, ids, *category.UserID, unique)
+			if err != nil {
+				return err
+			}
+			if taken {
+				return ErrTaskTitleDuplicate
+			}
+
+			restoreTasks := tx.Unscoped().Model(&model.Task{}).Where("id IN ?", ids).Update("deleted_at", nil)
+			if isDuplicate(restoreTasks.Error) {
+				return ErrTaskTitleDuplicate
+			}
+			if restoreTasks.Error != nil {
+				return restoreTasks.Error
+			}
+		}
+
+		restore := tx.Unscoped().Model(&model.Category{}).Where("id = ? AND user_id = ?", category.ID, category.UserID).Update("deleted_at", nil)
+		if isDuplicate(restore.Error) {
+			return ErrCategoryNameDuplicate
+		}
+
+		return restore.Error
+	})
+}
+
+// categoryNameTaken checks whether a category out of the trash has the name of the category
+func categoryNameTaken(tx *gorm.DB, category model.Category) (bool, error) {
+	var taken int64
+	err := tx.Model(&model.Category{}).Where("user_id = ? AND name = ? AND id <> ?", category.UserID, category.Name, category.ID).Count(&taken).Error
+	return taken > 0, err
+}
+
+// PurgeCategory deletes the category permanently, its tasks are removed by the foreign key
+func (db *categoryConnection) PurgeCategory(id int64, user_id int64) error {
+	return db.connection.Unscoped().Where("user_id = ?", user_id).Delete(&model.Category{}, id).Error
+}
+
+// PurgeTrashedCategories empties the user's trash of categories
+func (db *categoryConnection) PurgeTrashedCategories(user_id int64) (purged int64, err error) {
+	delete := db.connection.Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", user_id).Delete(&model.Category{})
+	return delete.RowsAffected, delete.Error
+}
+
+// PurgeExpiredCategories deletes permanently the categories in the trash since before
+func (db *categoryConnection) PurgeExpiredCategories(before time.Time) (purged int64, err error) {
+	delete := db.connection.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Delete(&model.Category{})
+	return delete.RowsAffected, delete.Error
+}
@@ -0,0 +1,16 @@
+package entity
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// MySQL error number of a duplicate key
+const mysqlDuplicateEntry = 1062
+
+// isDuplicate checks whether the error is the violation of a unique index
+func isDuplicate(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry
+}
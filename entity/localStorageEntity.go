@@ -0,0 +1,159 @@
+package entity
+
+import (
+	"go-todolist/utils/storage"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// localStorageConnection stores the objects on the local filesystem, they are downloaded through signed URLs
+type localStorageConnection struct {
+	config storage.Config
+}
+
+func NewLocalStorageEntity(config storage.Config) S3Entity {
+	return &localStorageConnection{
+		config: config,
+	}
+}
+
+func (db *localStorageConnection) FileUpload(file string, uuidV4 string, contentType string, body io.Reader) (*FileUploadOutput, error) {
+	key := objectKey(file, uuidV4)
+	path, pathErr := db.config.LocalFile(key)
+	if pathErr != nil {
+		return nil, pathErr
+	}
+
+	mkdirErr := os.MkdirAll(filepath.Dir(path), 0755)
+	if mkdirErr != nil {
+		return nil, mkdirErr
+	}
+
+	dst, createErr := os.Create(path)
+	if createErr != nil {
+		return nil, createErr
+	}
+	defer dst.Close()
+
+	_, copyErr := io.Copy(dst, body)
+	if copyErr != nil {
+		return nil, copyErr
+	}
+
+	return &FileUploadOutput{Key: key, Location: db.config.SignedURL(key, db.config.URLTTL)}, nil
+}
+
+func (db *localStorageConnection) FileRemove(file string, uuidV4 string) error {
+	path, pathErr := db.config.LocalFile(objectKey(file, uuidV4))
+	if pathErr != nil {
+		return pathErr
+	}
+
+	removeErr := os.Remove(path)
+	if removeErr != nil && !os.IsNotExist(removeErr) {
+		return removeErr
+	}
+
+	// Remove the folders (uuid, thumbnails) once they are empty
+	root, _ := filepath.Abs(db.config.LocalPath)
+	for dir := filepath.Dir(path); dir != root && strings.HasPrefix(dir, root); dir = filepath.Dir(dir) {
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+
+	return nil
+}
+
+func (db *localStorageConnection) FileURL(file string, uuidV4 string, ttl time.Duration) (string, error) {
+	return db.config.SignedURL(objectKey(file, uuidV4), ttl), nil
+}
+
+func (db *localStorageConnection) FileUploadURL(file string, uuidV4 string, contentType string, ttl time.Duration) (string, error) {
+	return db.config.SignedUploadURL(objectKey(file, uuidV4), ttl), nil
+}
+
+func (db *localStorageConnection) FileStat(file string, uuidV4 string) (*FileInfo, error) {
+	path, pathErr := db.config.LocalFile(objectKey(file, uuidV4))
+	if pathErr != nil {
+		return nil, pathErr
+	}
+
+	stat, statErr := os.Stat(path)
+	if os.IsNotExist(statErr) {
+		return nil, ErrFileNotFound
+	}
+	if statErr != nil {
+		return nil, statErr
+	}
+
+	return &FileInfo{Size: stat.Size(), ContentType: mime.TypeByExtension(filepath.Ext(file))}, nil
+}
+
+func (db *localStorageConnection) FileRead(file string, uuidV4 string) (io.ReadCloser, error) {
+	path, pathErr := db.config.LocalFile(objectKey(file, uuidV4))
+	if pathErr != nil {
+		return nil, pathErr
+	}
+
+	f, openErr := os.Open(path)
+	if os.IsNotExist(openErr) {
+		return nil, ErrFileNotFound
+	}
+	if openErr != nil {
+		return nil, openErr
+	}
+
+	return f, nil
+}
+
+// Number of objects passed to the FileList callback at once, as a page of ListObjectsV2
+const localListPageSize = 1000
+
+func (db *localStorageConnection) FileList(fn func(objects []FileObject) error) error {
+	root, err := filepath.Abs(db.config.LocalPath)
+	if err != nil {
+		return err
+	}
+
+	objects := []FileObject{}
+	walkErr := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if os.IsNotExist(err) && path == root {
+			return filepath.SkipDir
+		}
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, FileObject{Key: filepath.ToSlash(rel), Size: info.Size(), LastModified: info.ModTime()})
+
+		if len(objects) >= localListPageSize {
+			err = fn(objects)
+			objects = []FileObject{}
+		}
+		return err
+	})
+	if walkErr != nil {
+		return walkErr
+	}
+
+	if len(objects) > 0 {
+		return fn(objects)
+	}
+
+	return nil
+}
@@ -11,11 +11,22 @@ var ctx = context.Background()
 
 type RedisEntity interface {
 	Set(key string, value interface{}, expire time.Duration) (string, error)
+	SetNX(key string, value interface{}, expire time.Duration) (bool, error)
 	Get(key string) (interface{}, error)
 	Del(key string) (interface{}, error)
+	GetDel(key string) (string, error)
 	GetInt(key string) (int, error)
 	IncrBy(key string, value int64) (uint64, error)
 	ExpireAt(key string, time time.Time) bool
+	SAdd(key string, members ...interface{}) (int64, error)
+	SMembers(key string) ([]string, error)
+	SRem(key string, members ...interface{}) (int64, error)
+	Expire(key string, expire time.Duration) bool
+	ZAdd(key string, score float64, member string) (int64, error)
+	ZScore(key string, member string) (float64, error)
+	ZCount(key string, min string, max string) (int64, error)
+	ZRem(key string, members ...interface{}) (int64, error)
+	ZRemRangeByScore(key string, min string, max string) (int64, error)
 }
 
 type redisConnection struct {
@@ -33,6 +44,12 @@ func (rdb *redisConnection) Set(key string, value interface{}, expire time.Durat
 	return val, err
 }
 
+// Set the key only if it does not exist yet, report whether it was set
+func (rdb *redisConnection) SetNX(key string, value interface{}, expire time.Duration) (bool, error) {
+	val, err := rdb.connection.SetNX(ctx, key, value, expire).Result()
+	return val, err
+}
+
 func (rdb *redisConnection) Get(key string) (interface{}, error) {
 	val, err := rdb.connection.Get(ctx, key).Result()
 	return val, err
@@ -43,6 +60,12 @@ func (rdb *redisConnection) Del(key string) (interface{}, error) {
 	return val, err
 }
 
+// Get the value and delete the key atomically
+func (rdb *redisConnection) GetDel(key string) (string, error) {
+	val, err := rdb.connection.GetDel(ctx, key).Result()
+	return val, err
+}
+
 func (rdb *redisConnection) GetInt(key string) (int, error) {
 	val, err := rdb.connection.Get(ctx, key).Int()
 	return val, err
@@ -59,3 +82,57 @@ func (rdb *redisConnection) ExpireAt(key string, time time.Time) bool {
 	val := rdb.connection.ExpireAt(ctx, key, time).Val()
 	return val
 }
+
+// Add members to a set
+func (rdb *redisConnection) SAdd(key string, members ...interface{}) (int64, error) {
+	val, err := rdb.connection.SAdd(ctx, key, members...).Result()
+	return val, err
+}
+
+// Get all members of a set
+func (rdb *redisConnection) SMembers(key string) ([]string, error) {
+	val, err := rdb.connection.SMembers(ctx, key).Result()
+	return val, err
+}
+
+// Remove members from a set
+func (rdb *redisConnection) SRem(key string, members ...interface{}) (int64, error) {
+	val, err := rdb.connection.SRem(ctx, key, members...).Result()
+	return val, err
+}
+
+// Expire time (relative)
+func (rdb *redisConnection) Expire(key string, expire time.Duration) bool {
+	val := rdb.connection.Expire(ctx, key, expire).Val()
+	return val
+}
+
+// Add a member to a sorted set
+func (rdb *redisConnection) ZAdd(key string, score float64, member string) (int64, error) {
+	val, err := rdb.connection.ZAdd(ctx, key, &redis.Z{Score: score, Member: member}).Result()
+	return val, err
+}
+
+// Get the score of a member of a sorted set, redis.Nil if it is not a member
+func (rdb *redisConnection) ZScore(key string, member string) (float64, error) {
+	val, err := rdb.connection.ZScore(ctx, key, member).Result()
+	return val, err
+}
+
+// Count the members of a sorted set with a score between min and max
+func (rdb *redisConnection) ZCount(key string, min string, max string) (int64, error) {
+	val, err := rdb.connection.ZCount(ctx, key, min, max).Result()
+	return val, err
+}
+
+// Remove members from a sorted set
+func (rdb *redisConnection) ZRem(key string, members ...interface{}) (int64, error) {
+	val, err := rdb.connection.ZRem(ctx, key, members...).Result()
+	return val, err
+}
+
+// Remove the members of a sorted set with a score between min and max
+func (rdb *redisConnection) ZRemRangeByScore(key string, min string, max string) (int64, error) {
+	val, err := rdb.connection.ZRemRangeByScore(ctx, key, min, max).Result()
+	return val, err
+}
@@ -3,39 +3,94 @@ package entity
 import (
 	"context"
 	"errors"
-	"go-todolist/utils/log"
-	"mime/multipart"
-	"os"
+	"go-todolist/utils/aws"
+	"go-todolist/utils/storage"
+	"io"
+	"strings"
+	"time"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
+	awsSDK "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
-	"github.com/joho/godotenv"
 )
 
+// S3Entity is the object storage, implemented by the s3 (AWS or S3-compatible) and the local filesystem drivers
 type S3Entity interface {
-	FileUpload(file *multipart.FileHeader, uuidV4 string) (*manager.UploadOutput, error)
+	FileUpload(file string, uuidV4 string, contentType string, body io.Reader) (*FileUploadOutput, error)
 	FileRemove(file string, uuidV4 string) error
+	// FileRead opens the stored object, ErrFileNotFound if it does not exist
+	FileRead(file string, uuidV4 string) (io.ReadCloser, error)
+	// FileURL returns a link to download the object, valid for ttl
+	FileURL(file string, uuidV4 string, ttl time.Duration) (string, error)
+	// FileUploadURL returns a link to upload the object directly with a PUT request, valid for ttl
+	FileUploadURL(file string, uuidV4 string, contentType string, ttl time.Duration) (string, error)
+	// FileStat returns the stored object, ErrFileNotFound if it does not exist
+	FileStat(file string, uuidV4 string) (*FileInfo, error)
+	// FileList calls fn with every stored object, page by page
+	FileList(fn func(objects []FileObject) error) error
+}
+
+// FileObject is a stored object listed by FileList
+type FileObject struct {
+	// Object key (uuid/filename)
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// SplitObjectKey returns the file and the uuid of an object key
+func SplitObjectKey(key string) (file string, uuidV4 string) {
+	uuidV4, file, _ = strings.Cut(key, "/")
+	return file, uuidV4
+}
+
+// FileInfo is the metadata of a stored object
+type FileInfo struct {
+	Size        int64
+	ContentType string
+}
+
+var ErrFileNotFound = errors.New("File not found.")
+
+// FileUploadOutput is the uploaded object
+type FileUploadOutput struct {
+	// Object key (uuid/filename)
+	Key string
+	// Link to the object
+	Location string
+}
+
+// NewStorageEntity creates the S3Entity of the configured driver
+func NewStorageEntity(config storage.Config) S3Entity {
+	switch config.Driver {
+	case storage.DriverLocal:
+		return NewLocalStorageEntity(config)
+	case storage.DriverS3, storage.DriverMinio:
+		return NewS3Entity(aws.InitS3(config), config.Bucket)
+	default:
+		panic("Unknown STORAGE_DRIVER : " + config.Driver)
+	}
 }
 
 type s3Connection struct {
 	connection *s3.Client
+	bucket     string
 }
 
-func NewS3Entity(db *s3.Client) S3Entity {
+func NewS3Entity(db *s3.Client, bucket string) S3Entity {
 	return &s3Connection{
 		connection: db,
+		bucket:     bucket,
 	}
 }
 
-func (db *s3Connection) FileUpload(file *multipart.FileHeader, uuidV4 string) (*manager.UploadOutput, error) {
-	errEnv := godotenv.Load()
-	if errEnv != nil {
-		log.Panic("Failed to load env file")
-	}
-	bucker := os.Getenv("AWS_BUCKET")
+// objectKey is the key of the file, files are grouped by uuid
+func objectKey(file string, uuidV4 string) string {
+	return uuidV4 + "/" + file
+}
 
+func (db *s3Connection) FileUpload(file string, uuidV4 string, contentType string, body io.Reader) (*FileUploadOutput, error) {
 	client := db.connection
 	if client == nil {
 		return nil, errors.New("Invalid credential.")
@@ -46,15 +101,12 @@ func (db *s3Connection) FileUpload(file *multipart.FileHeader, uuidV4 string) (*
 		u.PartSize = 10 * 1024 * 1024
 	})
 
-	f, openErr := file.Open()
-	if openErr != nil {
-		return nil, openErr
-	}
-
+	key := objectKey(file, uuidV4)
 	result, resulterr := uploader.Upload(context.TODO(), &s3.PutObjectInput{
-		Bucket: aws.String(bucker),
-		Key:    aws.String(uuidV4 + "/" + file.Filename),
-		Body:   f,
+		Bucket:      awsSDK.String(db.bucket),
+		Key:         awsSDK.String(key),
+		Body:        body,
+		ContentType: awsSDK.String(contentType),
 		// ACL:    "public-read",
 	})
 
@@ -62,25 +114,19 @@ func (db *s3Connection) FileUpload(file *multipart.FileHeader, uuidV4 string) (*
 		return nil, resulterr
 	}
 
-	return result, nil
+	return &FileUploadOutput{Key: key, Location: result.Location}, nil
 }
 
 func (db *s3Connection) FileRemove(file string, uuidV4 string) error {
-	errEnv := godotenv.Load()
-	if errEnv != nil {
-		log.Panic("Failed to load env file")
-	}
-
-	bucker := os.Getenv("AWS_BUCKET")
 	var objectIds []types.ObjectIdentifier
 	client := db.connection
 	if client == nil {
 		return errors.New("Invalid credential.")
 	}
 
-	objectIds = append(objectIds, types.ObjectIdentifier{Key: aws.String(uuidV4 + "/" + file)})
+	objectIds = append(objectIds, types.ObjectIdentifier{Key: awsSDK.String(objectKey(file, uuidV4))})
 	_, err := client.DeleteObjects(context.TODO(), &s3.DeleteObjectsInput{
-		Bucket: aws.String(bucker),
+		Bucket: awsSDK.String(db.bucket),
 		Delete: &types.Delete{Objects: objectIds},
 	})
 	if err != nil {
@@ -89,3 +135,114 @@ func (db *s3Connection) FileRemove(file string, uuidV4 string) error {
 
 	return nil
 }
+
+func (db *s3Connection) FileURL(file string, uuidV4 string, ttl time.Duration) (string, error) {
+	client := db.connection
+	if client == nil {
+		return "", errors.New("Invalid credential.")
+	}
+
+	presignClient := s3.NewPresignClient(client)
+	request, err := presignClient.PresignGetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket: awsSDK.String(db.bucket),
+		Key:    awsSDK.String(objectKey(file, uuidV4)),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", err
+	}
+
+	return request.URL, nil
+}
+
+func (db *s3Connection) FileUploadURL(file string, uuidV4 string, contentType string, ttl time.Duration) (string, error) {
+	client := db.connection
+	if client == nil {
+		return "", errors.New("Invalid credential.")
+	}
+
+	presignClient := s3.NewPresignClient(client)
+	request, err := presignClient.PresignPutObject(context.TODO(), &s3.PutObjectInput{
+		Bucket:      awsSDK.String(db.bucket),
+		Key:         awsSDK.String(objectKey(file, uuidV4)),
+		ContentType: awsSDK.String(contentType),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", err
+	}
+
+	return request.URL, nil
+}
+
+func (db *s3Connection) FileStat(file string, uuidV4 string) (*FileInfo, error) {
+	client := db.connection
+	if client == nil {
+		return nil, errors.New("Invalid credential.")
+	}
+
+	head, err := client.HeadObject(context.TODO(), &s3.HeadObjectInput{
+		Bucket: awsSDK.String(db.bucket),
+		Key:    awsSDK.String(objectKey(file, uuidV4)),
+	})
+	if err != nil {
+		var notFound *types.NotFound
+		if errors.As(err, &notFound) {
+			return nil, ErrFileNotFound
+		}
+		return nil, err
+	}
+
+	return &FileInfo{Size: head.ContentLength, ContentType: awsSDK.ToString(head.ContentType)}, nil
+}
+
+func (db *s3Connection) FileRead(file string, uuidV4 string) (io.ReadCloser, error) {
+	client := db.connection
+	if client == nil {
+		return nil, errors.New("Invalid credential.")
+	}
+
+	object, err := client.GetObject(context.TODO(), &s3.GetObjectInput{
+		Bucket: awsSDK.String(db.bucket),
+		Key:    awsSDK.String(objectKey(file, uuidV4)),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, ErrFileNotFound
+		}
+		return nil, err
+	}
+
+	return object.Body, nil
+}
+
+func (db *s3Connection) FileList(fn func(objects []FileObject) error) error {
+	client := db.connection
+	if client == nil {
+		return errors.New("Invalid credential.")
+	}
+
+	paginator := s3.NewListObjectsV2Paginator(client, &s3.ListObjectsV2Input{
+		Bucket: awsSDK.String(db.bucket),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(context.TODO())
+		if err != nil {
+			return err
+		}
+
+		objects := make([]FileObject, 0, len(page.Contents))
+		for _, object := range page.Contents {
+			objects = append(objects, FileObject{
+				Key:          awsSDK.ToString(object.Key),
+				Size:         object.Size,
+				LastModified: awsSDK.ToTime(object.LastModified),
+			})
+		}
+
+		if err := fn(objects); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package entity
+
+import (
+	"go-todolist/model"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/paginator"
+	"go-todolist/utils/responses"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TagEntity interface {
+	CreateTag(tag model.Tag) (c model.Tag, e error)
+	GetTagList(user_id int64, name string, page int64, limit int64) paginator.Page[model.Tag]
+	GetTag(id int64, user_id int64) (tag model.Tag, err error)
+	CountTags(ids []int64, user_id int64) (count int64, err error)
+	UpdateTag(tag model.Tag) (c model.Tag, e error)
+	DeleteTag(id int64, user_id int64) error
+	AttachTaskTags(task_id int64, ids []int64) error
+	DetachTaskTag(task_id int64, id int64) (detached bool, err error)
+}
+
+var (
+	ErrTagNameDuplicate = apperr.New(apperr.Conflict, responses.TagNameDuplicate)
+	ErrTagNotFound      = apperr.New(apperr.Validation, responses.TagNotFound)
+)
+
+type tagConnection struct {
+	connection *gorm.DB
+}
+
+func NewTagEntity(db *gorm.DB) TagEntity {
+	return &tagConnection{
+		connection: db,
+	}
+}
+
+// CreateTag creates the tag, ErrTagNameDuplicate if the user already has the name
+func (db *tagConnection) CreateTag(tag model.Tag) (c model.Tag, e error) {
+	create := db.connection.Create(&tag)
+	if isDuplicate(create.Error) {
+		return tag, ErrTagNameDuplicate
+	}
+	if create.Error != nil {
+		return tag, create.Error
+	}
+
+	return tag, nil
+}
+
+func (db *tagConnection) GetTagList(user_id int64, name string, page int64, limit int64) paginator.Page[model.Tag] {
+	var tags []*model.Tag
+	query := db.connection.Model(&tags).Where("user_id = ?", user_id).Order("name")
+
+	if len(name) > 0 {
+		query.Where("name like ?", name+"%")
+	}
+
+	p := paginator.Page[model.Tag]{CurrentPage: page, PageLimit: limit}
+	p.SelectPages(query)
+
+	return p
+}
+
+func (db *tagConnection) GetTag(id int64, user_id int64) (tag model.Tag, err error) {
+	res := db.connection.First(&tag, "id = ? AND user_id = ?", id, user_id)
+	if res.Error == gorm.ErrRecordNotFound {
+		return tag, apperr.ErrRecordNotFound
+	}
+
+	return tag, res.Error
+}
+
+// CountTags counts the tags of the user among the ids
+func (db *tagConnection) CountTags(ids []int64, user_id int64) (count int64, err error) {
+	err = db.connection.Model(&model.Tag{}).Where("id IN ? AND user_id = ?", ids, user_id).Count(&count).Error
+
+	return count, err
+}
+
+func (db *tagConnection) UpdateTag(tag model.Tag) (c model.Tag, e error) {
+	update := db.connection.Where("id = ? AND user_id = ?", tag.ID, tag.UserID).Updates(&tag)
+	if isDuplicate(update.Error) {
+		return tag, ErrTagNameDuplicate
+	}
+	if update.Error != nil {
+		return tag, update.Error
+	}
+
+	return db.GetTag(tag.ID, tag.UserID)
+}
+
+// DeleteTag deletes the tag, it is detached from its tasks by the foreign key
+func (db *tagConnection) DeleteTag(id int64, user_id int64) error {
+	return db.connection.Where("user_id = ?", user_id).Delete(&model.Tag{}, id).Error
+}
+
+// AttachTaskTags attaches the tags to the task, the tags already attached are left as is
+func (db *tagConnection) AttachTaskTags(task_id int64, ids []int64) error {
+	taskTags := make([]model.TaskTag, len(ids))
+	for i, id := range ids {
+		taskTags[i] = model.TaskTag{TaskID: task_id, TagID: id}
+	}
+
+	return db.connection.Clauses(clause.OnConflict{DoNothing: true}).Create(&taskTags).Error
+}
+
+func (db *tagConnection) DetachTaskTag(task_id int64, id int64) (detached bool, err error) {
+	res := db.connection.Where("task_id = ? AND tag_id = ?", task_id, id).Delete(&model.TaskTag{})
+
+	return res.RowsAffected > 0, res.Error
+}
@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"go-todolist/model"
+	"go-todolist/utils/apperr"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TaskAttachmentEntity interface {
+	Transaction(fc func(tx TaskAttachmentEntity) error) error
+	LockTaskAttachments(task_id int64) error
+	CreateTaskAttachment(attachment model.TaskAttachment) (c model.TaskAttachment, e error)
+	GetTaskAttachmentList(task_id int64) (attachments []model.TaskAttachment, err error)
+	GetTaskAttachment(id int64, task_id int64) (attachment model.TaskAttachment, err error)
+	CountTaskAttachment(task_id int64) (count int64, err error)
+	DeleteTaskAttachment(id int64, task_id int64) error
+	GetTaskAttachmentsByUuids(uuids []string) (attachments []model.TaskAttachment, err error)
+}
+
+type taskAttachmentConnection struct {
+	connection *gorm.DB
+}
+
+func NewTaskAttachmentEntity(db *gorm.DB) TaskAttachmentEntity {
+	return &taskAttachmentConnection{
+		connection: db,
+	}
+}
+
+// Transaction runs fc with the entity of a transaction, the writes of fc are rolled back if it returns an error
+func (db *taskAttachmentConnection) Transaction(fc func(tx TaskAttachmentEntity) error) error {
+	return db.connection.Transaction(func(tx *gorm.DB) error {
+		return fc(&taskAttachmentConnection{connection: tx})
+	})
+}
+
+// LockTaskAttachments locks the row of the task until the end of the transaction,
+// so that the attachments of the task are counted and recorded one request after the other
+func (db *taskAttachmentConnection) LockTaskAttachments(task_id int64) error {
+	var ids []int64
+	res := db.connection.Model(&model.Task{}).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", task_id).Pluck("id", &ids)
+	if res.Error != nil {
+		return res.Error
+	}
+	if len(ids) == 0 {
+		return apperr.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+func (db *taskAttachmentConnection) CreateTaskAttachment(attachment model.TaskAttachment) (c model.TaskAttachment, e error) {
+	create := db.connection.Create(&attachment)
+	if create.Error != nil {
+		return attachment, create.Error
+	}
+
+	return attachment, nil
+}
+
+func (db *taskAttachmentConnection) GetTaskAttachmentList(task_id int64) (attachments []model.TaskAttachment, err error) {
+	res := db.connection.Where("task_id = ?", task_id).Order("id").Find(&attachments)
+
+	return attachments, res.Error
+}
+
+func (db *taskAttachmentConnection) GetTaskAttachment(id int64, task_id int64) (attachment model.TaskAttachment, err error) {
+	res := db.connection.First(&attachment, "id = ? AND task_id = ?", id, task_id)
+	if res.Error == gorm.ErrRecordNotFound {
+		return attachment, apperr.ErrRecordNotFound
+	}
+
+	return attachment, res.Error
+}
+
+func (db *taskAttachmentConnection) CountTaskAttachment(task_id int64) (count int64, err error) {
+	res := db.connection.Model(&model.TaskAttachment{}).Where("task_id = ?", task_id).Count(&count)
+
+	return count, res.Error
+}
+
+func (db *taskAttachmentConnection) DeleteTaskAttachment(id int64, task_id int64) error {
+	return db.connection.Where("task_id = ?", task_id).Delete(&model.TaskAttachment{}, id).Error
+}
+
+// GetTaskAttachmentsByUuids gets the attachments of every task stored under the uuids
+func (db *taskAttachmentConnection) GetTaskAttachmentsByUuids(uuids []string) (attachments []model.TaskAttachment, err error) {
+	if len(uuids) == 0 {
+		return attachments, nil
+	}
+
+	res := db.connection.Where("uuid IN ?", uuids).Find(&attachments)
+
+	return attachments, res.Error
+}
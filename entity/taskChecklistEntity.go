@@ -0,0 +1,93 @@
+package entity
+
+import (
+	"go-todolist/model"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/responses"
+
+	"gorm.io/gorm"
+)
+
+type TaskChecklistEntity interface {
+	CreateTaskChecklistItem(item model.TaskChecklistItem) (c model.TaskChecklistItem, e error)
+	GetTaskChecklist(task_id int64) (items []model.TaskChecklistItem, err error)
+	GetTaskChecklistItem(id int64, task_id int64) (item model.TaskChecklistItem, err error)
+	UpdateTaskChecklistItem(id int64, task_id int64, values map[string]interface{}) (c model.TaskChecklistItem, e error)
+	DeleteTaskChecklistItem(id int64, task_id int64) error
+	ReorderTaskChecklist(task_id int64, ids []int64) (items []model.TaskChecklistItem, err error)
+}
+
+var ErrChecklistOrderInvalid = apperr.New(apperr.Validation, responses.ChecklistOrderInvalid)
+
+type taskChecklistConnection struct {
+	connection *gorm.DB
+}
+
+func NewTaskChecklistEntity(db *gorm.DB) TaskChecklistEntity {
+	return &taskChecklistConnection{
+		connection: db,
+	}
+}
+
+// CreateTaskChecklistItem appends the item to the end of the checklist
+func (db *taskChecklistConnection) CreateTaskChecklistItem(item model.TaskChecklistItem) (c model.TaskChecklistItem, e error) {
+	err := db.connection.Transaction(func(tx *gorm.DB) error {
+		var last int
+		if err := tx.Model(&model.TaskChecklistItem{}).Where("task_id = ?", item.TaskID).Select("COALESCE(MAX(position), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+
+		item.Position = last + 1
+		return tx.Create(&item).Error
+	})
+
+	return item, err
+}
+
+func (db *taskChecklistConnection) GetTaskChecklist(task_id int64) (items []model.TaskChecklistItem, err error) {
+	res := db.connection.Where("task_id = ?", task_id).Scopes(orderChecklist).Find(&items)
+
+	return items, res.Error
+}
+
+func (db *taskChecklistConnection) GetTaskChecklistItem(id int64, task_id int64) (item model.TaskChecklistItem, err error) {
+	res := db.connection.First(&item, "id = ? AND task_id = ?", id, task_id)
+	if res.Error == gorm.ErrRecordNotFound {
+		return item, apperr.ErrRecordNotFound
+	}
+
+	return item, res.Error
+}
+
+// UpdateTaskChecklistItem updates the item with a map, so that is_done can be set to false
+func (db *taskChecklistConnection) UpdateTaskChecklistItem(id int64, task_id int64, values map[string]interface{}) (c model.TaskChecklistItem, e error) {
+	update := db.connection.Model(&model.TaskChecklistItem{}).Where("id = ? AND task_id = ?", id, task_id).Updates(values)
+	if update.Error != nil {
+		return c, update.Error
+	}
+
+	return db.GetTaskChecklistItem(id, task_id)
+}
+
+func (db *taskChecklistConnection) DeleteTaskChecklistItem(id int64, task_id int64) error {
+	return db.connection.Where("task_id = ?", task_id).Delete(&model.TaskChecklistItem{}, id).Error
+}
+
+// ReorderTaskChecklist sets the positions of the items in the order of the ids
+func (db *taskChecklistConnection) ReorderTaskChecklist(task_id int64, ids []int64) (items []model.TaskChecklistItem, err error) {
+	err = db.connection.Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			update := tx.Model(&model.TaskChecklistItem{}).Where("id = ? AND task_id = ?", id, task_id).Update("position", i+1)
+			if update.Error != nil {
+				return update.Error
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return items, err
+	}
+
+	return db.GetTaskChecklist(task_id)
+}
@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"go-todolist/model"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/responses"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrTaskDependencyCycle  = apperr.New(apperr.Validation, responses.TaskDependencyCycle)
+	ErrBlockingTaskNotFound = apperr.New(apperr.Validation, responses.BlockingTaskNotFound)
+)
+
+type TaskDependencyEntity interface {
+	CreateTaskDependency(task_id int64, blocked_by_id int64, user_id int64) error
+	DeleteTaskDependency(task_id int64, blocked_by_id int64) (deleted bool, err error)
+}
+
+type taskDependencyConnection struct {
+	connection *gorm.DB
+}
+
+func NewTaskDependencyEntity(db *gorm.DB) TaskDependencyEntity {
+	return &taskDependencyConnection{
+		connection: db,
+	}
+}
+
+// CreateTaskDependency blocks the task by another one of the user, ErrTaskDependencyCycle if the other task is (transitively) blocked by the task.
+// Adding an existing link does nothing
+func (db *taskDependencyConnection) CreateTaskDependency(task_id int64, blocked_by_id int64, user_id int64) error {
+	return db.connection.Transaction(func(tx *gorm.DB) error {
+		// The links of the user are created one at a time (the row of the user is locked until the end of the transaction),
+		// otherwise two links checked at the same time could close a cycle together
+		var users []int64
+		if err := tx.Model(&model.User{}).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", user_id).Pluck("id", &users).Error; err != nil {
+			return err
+		}
+
+		blockers, err := blockerIDs(tx, blocked_by_id)
+		if err != nil {
+			return err
+		}
+		if blocked_by_id == task_id || blockers[task_id] {
+			return ErrTaskDependencyCycle
+		}
+
+		dependency := model.TaskDependency{TaskID: task_id, BlockedByID: blocked_by_id}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&dependency).Error
+	})
+}
+
+// blockerIDs gets the tasks blocking the task, directly or through other tasks. The links of the tasks in the trash
+// are followed as well, so that restoring a task can not bring a cycle back
+func blockerIDs(tx *gorm.DB, id int64) (map[int64]bool, error) {
+	seen := map[int64]bool{}
+	ids := []int64{id}
+	for len(ids) > 0 {
+		var blockers []int64
+		if err := tx.Model(&model.TaskDependency{}).Where("task_id IN ?", ids).Pluck("blocked_by_id", &blockers).Error; err != nil {
+			return nil, err
+		}
+
+		ids = []int64{}
+		for _, blocker := range blockers {
+			if !seen[blocker] {
+				seen[blocker] = true
+				ids = append(ids, blocker)
+			}
+		}
+	}
+
+	return seen, nil
+}
+
+func (db *taskDependencyConnection) DeleteTaskDependency(task_id int64, blocked_by_id int64) (deleted bool, err error) {
+	res := db.connection.Where("task_id = ? AND blocked_by_id = ?", task_id, blocked_by_id).Delete(&model.TaskDependency{})
+
+	return res.RowsAffected > 0, res.Error
+}
@@ -2,7 +2,11 @@ package entity
 
 import (
 	"go-todolist/model"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/civilDatetime"
 	"go-todolist/utils/paginator"
+	"go-todolist/utils/responses"
+	"go-todolist/utils/search"
 	"time"
 
 	"gorm.io/gorm"
@@ -10,12 +14,109 @@ import (
 )
 
 type TaskEntity interface {
-	CreateTask(task model.Task) (c model.Task, e error)
-	GetTaskList(id int64, user_id int64, title string, specify_datetime *time.Time, is_specify_time *bool, is_complete *bool, page int64, limit int64) paginator.Page[model.Task]
-	GetTask(id int64) (task model.Task, err error)
-	UpdateTask(task model.Task) (c model.Task, e error)
-	DeleteTask(id int64) (c model.Task, e error)
-	GetTaskImguuidByUserId(user_id int64) interface{}
+	CreateTask(task model.Task, unique string) (c model.Task, e error)
+	GetTaskList(filter TaskListFilter, page int64, limit int64) paginator.Page[model.Task]
+	GetTaskListByCursor(filter TaskListFilter, cursor string, limit int64, with_total bool) (paginator.CursorPage[model.Task], error)
+	GetTask(id int64, user_id int64) (task model.Task, err error)
+	LockTask(id int64, user_id int64) (task model.Task, err error)
+	UpdateTask(task model.Task, unique string) (c model.Task, e error)
+	DeleteTask(id int64, user_id int64) (c model.Task, e error)
+	CompleteOccurrence(task model.Task, next *time.Time, status_id int64) (c model.Task, e error)
+	MoveTask(id int64, user_id int64, status model.WorkflowStatus, position int) error
+	GetTaskOccurrenceList(task_id int64, page int64, limit int64) paginator.Page[model.TaskOccurrence]
+	GetDueTasks(now time.Time, limit int) (tasks []model.DueTask, err error)
+	UpdateTaskNotify(id int64, from int8, to int8) (updated bool, err error)
+	ResetTaskNotify(id int64) error
+	GetTaskDescendantIDs(id int64, user_id int64) ([]int64, error)
+	CompleteTasks(ids []int64, user_id int64, status_id int64) error
+	UpdateTaskParent(id int64, user_id int64, parent_id *int64) error
+	UpdateTaskDue(id int64, user_id int64, due *time.Time, is_specify_time bool) error
+	GetTrashedTaskList(user_id int64, page int64, limit int64) paginator.Page[model.Task]
+	GetTrashedTask(id int64, user_id int64) (task model.Task, err error)
+	RestoreTask(task model.Task, unique string) error
+	PurgeTask(id int64, user_id int64) error
+	PurgeTrashedTasks(user_id int64) (purged int64, err error)
+	PurgeExpiredTasks(before time.Time) (purged int64, err error)
+	SyncTitleScope(unique string) error
+	TitleScopeSynced(unique string) (bool, error)
+	Transaction(fc func(tx TaskEntity) error) error
+}
+
+// TaskListFilter are the conditions of GetTaskList, the zero values are ignored
+type TaskListFilter struct {
+	ID     int64
+	UserID int64
+	// Subtasks of the task
+	ParentID int64
+	// Tasks in the status (kanban column)
+	StatusID int64
+	// Prefix of the title
+	Title string
+	// Full-text search of the title, note and url
+	Q               string
+	SpecifyDatetime *time.Time
+	// Range of specify_datetime (inclusive)
+	DueFrom *time.Time
+	DueTo   *time.Time
+	// Range of created_at (inclusive)
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Priorities  []int8
+	CategoryIDs []int64
+	TagIDs      []int64
+	// TagMatchAny or TagMatchAll of the TagIDs
+	TagMatch      string
+	IsSpecifyTime *bool
+	IsComplete    *bool
+	// Whether one of the blocking tasks is not complete yet
+	IsBlocked *bool
+	// Due preset, DueOverdue, DueToday or DueUpcoming
+	Due  string
+	Sort clause.OrderBy
+	// Timezone of the user, the days of the due presets and of the tasks without a time are the ones in it
+	Location *time.Location
+}
+
+// Due presets of the task list
+const (
+	// Incomplete tasks past their specify_datetime
+	DueOverdue = "overdue"
+	// Tasks due today
+	DueToday = "today"
+	// Tasks due from tomorrow
+	DueUpcoming = "upcoming"
+)
+
+// Matches of the tags of the task list
+const (
+	// Tasks with one of the tags at least
+	TagMatchAny = "any"
+	// Tasks with every tag
+	TagMatchAll = "all"
+)
+
+// Scopes of the uniqueness of the task titles
+const (
+	// Titles can be repeated
+	TitleUniqueNone = "none"
+	// A title once among the tasks of the user
+	TitleUniqueUser = "user"
+	// A title once among the tasks of the user in the category
+	TitleUniqueCategory = "category"
+)
+
+var ErrTaskTitleDuplicate = apperr.New(apperr.Conflict, responses.TaskTitleDuplicate)
+
+// TaskSortColumns are the sort fields of the task list
+var TaskSortColumns = paginator.SortColumns{
+	"id":               "tasks.id",
+	"title":            "tasks.title",
+	"priority":         "tasks.priority",
+	"specify_datetime": "tasks.specify_datetime",
+	"is_complete":      "tasks.is_complete",
+	"position":         "tasks.position",
+	"created_at":       "tasks.created_at",
+	"updated_at":       "tasks.updated_at",
 }
 
 type taskConnection struct {
@@ -28,60 +129,270 @@ func NewTaskEntity(db *gorm.DB) TaskEntity {
 	}
 }
 
-func (db *taskConnection) CreateTask(task model.Task) (c model.Task, e error) {
-	create := db.connection.Save(&task)
-	if create.Error != nil {
-		return task, create.Error
+// Transaction runs fc with the entity of a transaction, the writes of fc are rolled back if it returns an error
+func (db *taskConnection) Transaction(fc func(tx TaskEntity) error) error {
+	return db.connection.Transaction(func(tx *gorm.DB) error {
+		return fc(&taskConnection{connection: tx})
+	})
+}
+
+// CreateTask creates the task at the end of its status column, ErrTaskTitleDuplicate if the title is taken in the unique scope
+func (db *taskConnection) CreateTask(task model.Task, unique string) (c model.Task, e error) {
+	task.TitleScope = TitleScope(task, unique)
+	err := db.connection.Transaction(func(tx *gorm.DB) error {
+		if task.StatusID != nil {
+			var last int
+			if err := tx.Model(&model.Task{}).Where("status_id = ? AND user_id = ?", task.StatusID, task.UserID).Select("COALESCE(MAX(position), 0)").Scan(&last).Error; err != nil {
+				return err
+			}
+			task.Position = last + 1
+		}
+
+		return tx.Save(&task).Error
+	})
+	if isDuplicate(err) {
+		return task, ErrTaskTitleDuplicate
+	}
+	if err != nil {
+		return task, err
 	}
 
 	return task, nil
 }
 
-func (db *taskConnection) GetTaskList(id int64, user_id int64, title string, specify_datetime *time.Time, is_specify_time *bool, is_complete *bool, page int64, limit int64) paginator.Page[model.Task] {
+func (db *taskConnection) GetTaskList(filter TaskListFilter, page int64, limit int64) paginator.Page[model.Task] {
+	query, relevance := db.taskListQuery(filter)
+
+	// The requested sort comes first, then the relevance of the search
+	order := []clause.Expression{}
+	if len(filter.Sort.Columns) > 0 {
+		order = append(order, filter.Sort)
+	}
+	if relevance != nil {
+		order = append(order, relevance)
+	}
+	if len(order) > 0 {
+		query.Clauses(clause.OrderBy{Expression: clause.CommaExpression{Exprs: order}})
+	}
+
+	p := paginator.Page[model.Task]{CurrentPage: page, PageLimit: limit}
+	p.SelectPages(query)
+
+	return p
+}
+
+// GetTaskListByCursor gets the tasks with the keyset pagination, in the requested sort then by id.
+// The search results are not ordered by relevance, it can not be used as a cursor
+func (db *taskConnection) GetTaskListByCursor(filter TaskListFilter, cursor string, limit int64, with_total bool) (paginator.CursorPage[model.Task], error) {
+	query, _ := db.taskListQuery(filter)
+
+	p := paginator.CursorPage[model.Task]{PageLimit: limit}
+	err := p.SelectCursor(query, filter.Sort, "tasks.id", cursor, with_total)
+
+	return p, err
+}
+
+// taskListQuery sets up the conditions of the filter, with the order by relevance of the search if any
+func (db *taskConnection) taskListQuery(filter TaskListFilter) (*gorm.DB, clause.Expression) {
 	var tasks []*model.Task
-	query := db.connection.Model(&tasks).Preload(clause.Associations)
+	// Tasks are always scoped to their owner
+	query := db.connection.Model(&tasks).Scopes(preloadTask).Where("user_id = ?", filter.UserID)
 
-	if id > 0 {
-		query.Where("id = ?", id)
+	if filter.ID > 0 {
+		query.Where("id = ?", filter.ID)
 	}
 
-	if user_id > 0 {
-		query.Where("user_id = ?", user_id)
+	if filter.ParentID > 0 {
+		query.Where("parent_id = ?", filter.ParentID)
 	}
 
-	if len(title) > 0 {
-		query.Where("title like ?", title+"%")
+	if filter.StatusID > 0 {
+		query.Where("status_id = ?", filter.StatusID)
 	}
 
-	if specify_datetime != nil {
-		query.Where("specify_datetime = ?", specify_datetime)
+	if len(filter.Title) > 0 {
+		query.Where("title like ?", filter.Title+"%")
 	}
 
-	if is_specify_time != nil {
-		query.Where("is_specify_time = ?", is_specify_time)
+	if filter.SpecifyDatetime != nil {
+		query.Where("specify_datetime = ?", filter.SpecifyDatetime)
 	}
 
-	if is_complete != nil {
-		query.Where("is_complete = ?", is_complete)
+	// A task without a time is compared by its date
+	if filter.DueFrom != nil {
+		from := civilDatetime.DateOf(filter.DueFrom.In(filter.location()))
+		query.Where("((is_specify_time = ? AND specify_datetime >= ?) OR (is_specify_time = ? AND specify_datetime >= ?))", true, filter.DueFrom, false, from.Time())
 	}
 
-	p := paginator.Page[model.Task]{CurrentPage: page, PageLimit: limit}
-	p.SelectPages(query)
+	if filter.DueTo != nil {
+		to := civilDatetime.DateOf(filter.DueTo.In(filter.location()))
+		query.Where("((is_specify_time = ? AND specify_datetime <= ?) OR (is_specify_time = ? AND specify_datetime <= ?))", true, filter.DueTo, false, to.Time())
+	}
 
-	return p
+	if filter.CreatedFrom != nil {
+		query.Where("created_at >= ?", filter.CreatedFrom)
+	}
+
+	if filter.CreatedTo != nil {
+		query.Where("created_at <= ?", filter.CreatedTo)
+	}
+
+	if len(filter.Priorities) > 0 {
+		query.Where("priority IN ?", filter.Priorities)
+	}
+
+	if len(filter.CategoryIDs) > 0 {
+		query.Where("category_id IN ?", filter.CategoryIDs)
+	}
+
+	if len(filter.TagIDs) > 0 {
+		query.Scopes(taggedWith(filter.TagIDs, filter.TagMatch))
+	}
+
+	if filter.IsSpecifyTime != nil {
+		query.Where("is_specify_time = ?", filter.IsSpecifyTime)
+	}
+
+	if filter.IsComplete != nil {
+		query.Where("is_complete = ?", filter.IsComplete)
+	}
+
+	if filter.IsBlocked != nil {
+		if *filter.IsBlocked {
+			query.Where("EXISTS ("+blockingTasks+")", false)
+		} else {
+			query.Where("NOT EXISTS ("+blockingTasks+")", false)
+		}
+	}
+
+	if filter.Due != "" {
+		query.Scopes(dueWithin(filter.Due, time.Now().In(filter.location())))
+	}
+
+	var relevance clause.Expression
+	if terms := search.Terms(filter.Q); len(terms) > 0 {
+		relevance = db.searchTasks(query, terms)
+	}
+
+	return query, relevance
 }
 
-func (db *taskConnection) GetTask(id int64) (task model.Task, err error) {
-	res := db.connection.Preload("Category").First(&task, "id=?", id)
-	if res.Error == nil {
-		return task, nil
+func (filter TaskListFilter) location() *time.Location {
+	if filter.Location == nil {
+		return time.UTC
 	}
 
-	return task, err
+	return filter.Location
 }
 
-func (db *taskConnection) UpdateTask(task model.Task) (c model.Task, e error) {
-	update := db.connection.Where("id=?", task.ID).Updates(&task)
+// taggedWith limits the query to the tasks with any or all of the tags
+func taggedWith(ids []int64, match string) func(db *gorm.DB) *gorm.DB {
+	unique := map[int64]bool{}
+	for _, id := range ids {
+		unique[id] = true
+	}
+
+	return func(db *gorm.DB) *gorm.DB {
+		if match == TagMatchAll {
+			return db.Where("(SELECT COUNT(DISTINCT task_tags.tag_id) FROM task_tags WHERE task_tags.task_id = tasks.id AND task_tags.tag_id IN ?) = ?", ids, len(unique))
+		}
+
+		return db.Where("EXISTS (SELECT 1 FROM task_tags WHERE task_tags.task_id = tasks.id AND task_tags.tag_id IN ?)", ids)
+	}
+}
+
+// blockingTasks selects the incomplete tasks blocking the task of the row, the ones in the trash do not block
+const blockingTasks = "SELECT 1 FROM task_dependencies JOIN tasks AS blockers ON blockers.id = task_dependencies.blocked_by_id " +
+	"WHERE task_dependencies.task_id = tasks.id AND blockers.is_complete = ? AND blockers.deleted_at IS NULL"
+
+// dueWithin limits the query to the tasks of a due preset, the days are the ones of the location of now.
+// A task without a specified time is due by the end of its day, so it is only overdue the day after.
+// Its specify_datetime is the start of its date in UTC, so it is compared with the dates instead of the instants
+func dueWithin(due string, now time.Time) func(db *gorm.DB) *gorm.DB {
+	today := civilDatetime.DateOf(now)
+	start := today.In(now.Location())
+	tomorrow := civilDatetime.DateOf(start.AddDate(0, 0, 1))
+	end := tomorrow.In(now.Location())
+
+	return func(db *gorm.DB) *gorm.DB {
+		switch due {
+		case DueOverdue:
+			return db.Where("is_complete = ?", false).
+				Where("((is_specify_time = ? AND specify_datetime < ?) OR (is_specify_time = ? AND specify_datetime < ?))", true, now, false, today.Time())
+		case DueToday:
+			return db.Where("((is_specify_time = ? AND specify_datetime >= ? AND specify_datetime < ?) OR (is_specify_time = ? AND specify_datetime = ?))", true, start, end, false, today.Time())
+		case DueUpcoming:
+			return db.Where("((is_specify_time = ? AND specify_datetime >= ?) OR (is_specify_time = ? AND specify_datetime >= ?))", true, end, false, tomorrow.Time())
+		}
+
+		return db
+	}
+}
+
+// preloadTask loads the associations of the task responses
+func preloadTask(db *gorm.DB) *gorm.DB {
+	return db.Preload("Category").
+		Preload("Status").
+		Preload("Tags", func(tx *gorm.DB) *gorm.DB { return tx.Order("name") }).
+		Preload("Attachments").
+		Preload("Checklist", orderChecklist).
+		Preload("Children", func(tx *gorm.DB) *gorm.DB { return tx.Order("id") }).
+		Preload("BlockedBy", func(tx *gorm.DB) *gorm.DB { return tx.Order("id") })
+}
+
+func orderChecklist(db *gorm.DB) *gorm.DB {
+	return db.Order("position, id")
+}
+
+// searchTasks limits the query to the tasks whose title, note or url contain every term and returns the order by relevance.
+// MySQL uses the FULLTEXT index ftidx_title_note_url, the other databases fall back to LIKE without relevance (nil)
+func (db *taskConnection) searchTasks(query *gorm.DB, terms []string) clause.Expression {
+	if db.connection.Dialector.Name() == "mysql" {
+		match := "MATCH (tasks.title, tasks.note, tasks.url) AGAINST (? IN BOOLEAN MODE)"
+		against := search.BooleanQuery(terms)
+		query.Where(match, against)
+		return clause.Expr{SQL: match + " DESC", Vars: []interface{}{against}}
+	}
+
+	for _, term := range terms {
+		pattern := search.LikePattern(term)
+		query.Where("(tasks.title LIKE ? ESCAPE '!' OR tasks.note LIKE ? ESCAPE '!' OR tasks.url LIKE ? ESCAPE '!')", pattern, pattern, pattern)
+	}
+
+	return nil
+}
+
+// GetTask gets the task of the user, apperr.ErrRecordNotFound (and an empty task) if there is none
+func (db *taskConnection) GetTask(id int64, user_id int64) (task model.Task, err error) {
+	res := db.connection.Scopes(preloadTask).First(&task, "id = ? AND user_id = ?", id, user_id)
+	if res.Error == gorm.ErrRecordNotFound {
+		return task, apperr.ErrRecordNotFound
+	}
+
+	return task, res.Error
+}
+
+// LockTask gets the task without its relations and locks its row until the end of the transaction
+func (db *taskConnection) LockTask(id int64, user_id int64) (task model.Task, err error) {
+	res := db.connection.Clauses(clause.Locking{Strength: "UPDATE"}).First(&task, "id = ? AND user_id = ?", id, user_id)
+	if res.Error == gorm.ErrRecordNotFound {
+		return task, apperr.ErrRecordNotFound
+	}
+
+	return task, res.Error
+}
+
+// UpdateTask updates the non-zero fields of the task, ErrTaskTitleDuplicate if the title is taken in the unique scope
+func (db *taskConnection) UpdateTask(task model.Task, unique string) (c model.Task, e error) {
+	// The scope follows the category
+	if unique == TitleUniqueCategory && task.CategoryID != 0 {
+		task.TitleScope = TitleScope(task, unique)
+	}
+
+	update := db.connection.Where("id = ? AND user_id = ?", task.ID, task.UserID).Updates(&task)
+	if isDuplicate(update.Error) {
+		return task, ErrTaskTitleDuplicate
+	}
 	if update.Error != nil {
 		return task, update.Error
 	}
@@ -89,22 +400,410 @@ func (db *taskConnection) UpdateTask(task model.Task) (c model.Task, e error) {
 	return task, nil
 }
 
-func (db *taskConnection) DeleteTask(id int64) (c model.Task, e error) {
+// TitleScope is the title_scope of the task: the titles are unique among the tasks of the user with the same scope
+// (0 for the user, the category id for the category), nil without uniqueness.
+// The unique index of the tasks enforces it, a duplicate fails with the MySQL error 1062
+func TitleScope(task model.Task, unique string) *int64 {
+	var scope int64
+	switch unique {
+	case TitleUniqueUser:
+		return &scope
+	case TitleUniqueCategory:
+		scope = task.CategoryID
+		return &scope
+	}
+
+	return nil
+}
+
+// titleScopeExpr is the title_scope of all the tasks for the unique scope, nil without uniqueness
+func titleScopeExpr(unique string) interface{} {
+	switch unique {
+	case TitleUniqueUser:
+		return 0
+	case TitleUniqueCategory:
+		return gorm.Expr("category_id")
+	}
+
+	return nil
+}
+
+// SyncTitleScope sets the title_scope of all the tasks (the ones in the trash included) to the unique scope,
+// it fails with the MySQL error 1062 if titles are already repeated in the scope
+func (db *taskConnection) SyncTitleScope(unique string) error {
+	scope := titleScopeExpr(unique)
+
+	// The rows already in the scope are left alone, updated_at is kept
+	return db.connection.Unscoped().Model(&model.Task{}).Where("NOT (title_scope <=> ?)", scope).UpdateColumn("title_scope", scope).Error
+}
+
+// TitleScopeSynced reports whether the title_scope of all the tasks (the ones in the trash included) is the unique scope
+func (db *taskConnection) TitleScopeSynced(unique string) (bool, error) {
+	var ids []int64
+	err := db.connection.Unscoped().Model(&model.Task{}).Where("NOT (title_scope <=> ?)", titleScopeExpr(unique)).Limit(1).Pluck("id", &ids).Error
+
+	return len(ids) == 0, err
+}
+
+// DeleteTask moves the task and its subtasks to the trash, they share the same deleted_at
+// so that restoring the task restores the subtasks deleted with it
+func (db *taskConnection) DeleteTask(id int64, user_id int64) (c model.Task, e error) {
 	task := model.Task{}
-	delete := db.connection.Delete(&task, id)
-	if delete.Error != nil {
-		return task, delete.Error
+	// deleted_at is stored in seconds
+	now := time.Now().Truncate(time.Second)
+	err := db.connection.Transaction(func(tx *gorm.DB) error {
+		ids, err := descendantIDs(tx, []int64{id}, user_id, nil)
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&task).Where("id IN ? AND user_id = ?", append(ids, id), user_id).Update("deleted_at", now).Error
+	})
+	if err != nil {
+		return task, err
 	}
 
 	return task, nil
 }
 
-func (db *taskConnection) GetTaskImguuidByUserId(user_id int64) interface{} {
-	task := model.Task{}
-	res := db.connection.Where("img_uuid IS NOT NULL AND user_id = ?", user_id).First(&task)
-	if res.Error != nil {
+// descendantIDs gets the subtasks of the tasks down to the leaves, the live ones
+// or, with deleted_at, the ones in the trash since then
+func descendantIDs(tx *gorm.DB, ids []int64, user_id int64, deleted_at *time.Time) ([]int64, error) {
+	descendants := []int64{}
+	seen := map[int64]bool{}
+	for _, id := range ids {
+		seen[id] = true
+	}
+
+	for len(ids) > 0 {
+		query := tx.Model(&model.Task{}).Where("parent_id IN ? AND user_id = ?", ids, user_id)
+		if deleted_at != nil {
+			query = query.Unscoped().Where("deleted_at = ?", deleted_at)
+		}
+
+		var children []int64
+		if err := query.Pluck("id", &children).Error; err != nil {
+			return nil, err
+		}
+
+		ids = []int64{}
+		for _, child := range children {
+			// A cycle would loop forever
+			if !seen[child] {
+				seen[child] = true
+				ids = append(ids, child)
+				descendants = append(descendants, child)
+			}
+		}
+	}
+
+	return descendants, nil
+}
+
+// GetTaskDescendantIDs gets the live subtasks of the task down to the leaves
+func (db *taskConnection) GetTaskDescendantIDs(id int64, user_id int64) ([]int64, error) {
+	return descendantIDs(db.connection, []int64{id}, user_id, nil)
+}
+
+// CompleteTasks moves the incomplete tasks to the end of the done status
+func (db *taskConnection) CompleteTasks(ids []int64, user_id int64, status_id int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return db.connection.Transaction(func(tx *gorm.DB) error {
+		var incomplete []int64
+		if err := tx.Model(&model.Task{}).Where("id IN ? AND user_id = ? AND is_complete = ?", ids, user_id, false).Order("id").Pluck("id", &incomplete).Error; err != nil {
+			return err
+		}
+
+		for _, id := range incomplete {
+			if err := moveTask(tx, id, user_id, status_id, true, 0); err != nil {
+				return err
+			}
+		}
+
 		return nil
+	})
+}
+
+// MoveTask moves the task to the position (from 1) of the status column, 0 or past the end is the end of the column.
+// is_complete follows the status
+func (db *taskConnection) MoveTask(id int64, user_id int64, status model.WorkflowStatus, position int) error {
+	return db.connection.Transaction(func(tx *gorm.DB) error {
+		return moveTask(tx, id, user_id, status.ID, status.IsDone, position)
+	})
+}
+
+// moveTask renumbers the column with the task at its position, the rows of the column are locked until the end of the transaction
+func moveTask(tx *gorm.DB, id int64, user_id int64, status_id int64, is_done bool, position int) error {
+	var column []int64
+	err := tx.Model(&model.Task{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("status_id = ? AND user_id = ? AND id <> ?", status_id, user_id, id).Order("position, id").Pluck("id", &column).Error
+	if err != nil {
+		return err
 	}
 
-	return task.ImgUuid
+	if position < 1 || position > len(column)+1 {
+		position = len(column) + 1
+	}
+	column = append(column[:position-1], append([]int64{id}, column[position-1:]...)...)
+
+	values := map[string]interface{}{"status_id": status_id, "position": position, "is_complete": is_done}
+	if err := tx.Model(&model.Task{}).Where("id = ? AND user_id = ?", id, user_id).Updates(values).Error; err != nil {
+		return err
+	}
+
+	// The other tasks are only reordered, their updated_at is kept
+	for i, other := range column {
+		if other == id {
+			continue
+		}
+		if err := tx.Model(&model.Task{}).Where("id = ? AND position <> ?", other, i+1).UpdateColumn("position", i+1).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateTaskParent moves the task under the parent, nil makes it a top-level task
+func (db *taskConnection) UpdateTaskParent(id int64, user_id int64, parent_id *int64) error {
+	return db.connection.Model(&model.Task{}).Where("id = ? AND user_id = ?", id, user_id).Update("parent_id", parent_id).Error
+}
+
+// UpdateTaskDue sets specify_datetime and is_specify_time together, so that is_specify_time can be set to false
+func (db *taskConnection) UpdateTaskDue(id int64, user_id int64, due *time.Time, is_specify_time bool) error {
+	values := map[string]interface{}{
+		"specify_datetime": due,
+		"is_specify_time":  is_specify_time,
+	}
+
+	return db.connection.Model(&model.Task{}).Where("id = ? AND user_id = ?", id, user_id).Updates(values).Error
+}
+
+// CompleteOccurrence keeps the current occurrence as history and moves the task to the next one (nil when the series has ended),
+// at the end of the status, the first status for the next occurrence or the done status for the end of the series
+func (db *taskConnection) CompleteOccurrence(task model.Task, next *time.Time, status_id int64) (c model.Task, e error) {
+	now := time.Now()
+	err := db.connection.Transaction(func(tx *gorm.DB) error {
+		history := model.TaskOccurrence{
+			TaskID:          task.ID,
+			Occurrence:      task.Occurrence,
+			SpecifyDatetime: task.SpecifyDatetime,
+			CompletedAt:     &now,
+		}
+		if err := tx.Create(&history).Error; err != nil {
+			return err
+		}
+
+		if next == nil {
+			return moveTask(tx, task.ID, task.UserID, status_id, true, 0)
+		}
+
+		// Updates with a map, so that false is written as well
+		values := map[string]interface{}{
+			"specify_datetime": next,
+			"is_notify":        model.NotifyNone,
+			"occurrence":       gorm.Expr("occurrence + 1"),
+		}
+		if err := tx.Model(&model.Task{}).Where("id = ? AND user_id = ?", task.ID, task.UserID).Updates(values).Error; err != nil {
+			return err
+		}
+
+		return moveTask(tx, task.ID, task.UserID, status_id, false, 0)
+	})
+	if err != nil {
+		return task, err
+	}
+
+	return db.GetTask(task.ID, task.UserID)
+}
+
+func (db *taskConnection) GetTaskOccurrenceList(task_id int64, page int64, limit int64) paginator.Page[model.TaskOccurrence] {
+	var occurrences []*model.TaskOccurrence
+	query := db.connection.Model(&occurrences).Where("task_id = ?", task_id).Order("occurrence desc")
+
+	p := paginator.Page[model.TaskOccurrence]{CurrentPage: page, PageLimit: limit}
+	p.SelectPages(query)
+
+	return p
+}
+
+// GetDueTasks gets the incomplete, not yet notified tasks that are due and whose owner linked Telegram.
+// A task without a time is due from the start of its date in the timezone of its owner, see model.DueTask.IsDue,
+// the date is compared in SQL for each timezone of the owners so that the limit only counts the due tasks
+func (db *taskConnection) GetDueTasks(now time.Time, limit int) (tasks []model.DueTask, err error) {
+	var timezones []*string
+	if err := db.connection.Table("users").Distinct("timezone").Where("telegram_id IS NOT NULL").Pluck("timezone", &timezones).Error; err != nil {
+		return tasks, err
+	}
+
+	due := db.connection.Where("tasks.is_specify_time = ? AND tasks.specify_datetime <= ?", true, now)
+	for _, timezone := range timezones {
+		// The date of the owner is stored as its start in UTC, like the dates of the tasks
+		today := civilDatetime.DateOf(now.In(model.DueTask{Timezone: timezone}.Location())).Time()
+		due = due.Or("tasks.is_specify_time = ? AND users.timezone <=> ? AND tasks.specify_datetime <= ?", false, timezone, today)
+	}
+
+	res := db.connection.Table("tasks").
+		Select("tasks.id, tasks.user_id, users.telegram_id, tasks.title, tasks.note, tasks.url, tasks.specify_datetime, tasks.is_specify_time, users.timezone").
+		Joins("JOIN users ON users.id = tasks.user_id").
+		Where("tasks.is_notify = ? AND tasks.is_complete = ?", model.NotifyNone, false).
+		Where("tasks.specify_datetime IS NOT NULL").
+		Where(due).
+		// Table() does not apply the soft delete scope
+		Where("tasks.deleted_at IS NULL").
+		Where("users.telegram_id IS NOT NULL").
+		Order("tasks.specify_datetime").
+		Limit(limit).
+		Scan(&tasks)
+
+	return tasks, res.Error
+}
+
+// UpdateTaskNotify changes is_notify only if it still has the expected value (compare and swap),
+// so that only one replica can claim a reminder
+func (db *taskConnection) UpdateTaskNotify(id int64, from int8, to int8) (updated bool, err error) {
+	update := db.connection.Model(&model.Task{}).Where("id = ? AND is_notify = ?", id, from).Update("is_notify", to)
+	if update.Error != nil {
+		return false, update.Error
+	}
+
+	return update.RowsAffected == 1, nil
+}
+
+// ResetTaskNotify marks the task as not notified, e.g. after its specify_datetime is changed
+func (db *taskConnection) ResetTaskNotify(id int64) error {
+	return db.connection.Model(&model.Task{}).Where("id = ?", id).Update("is_notify", model.NotifyNone).Error
+}
+
+// GetTrashedTaskList gets the user's tasks in the trash, the most recently deleted first
+func (db *taskConnection) GetTrashedTaskList(user_id int64, page int64, limit int64) paginator.Page[model.Task] {
+	var tasks []*model.Task
+	// The category may be in the trash as well
+	query := db.connection.Unscoped().Model(&tasks).
+		Preload("Category", func(tx *gorm.DB) *gorm.DB { return tx.Unscoped() }).
+		Preload("Attachments").
+		Preload("Checklist", orderChecklist).
+		Where("user_id = ? AND deleted_at IS NOT NULL", user_id).
+		Order("deleted_at desc")
+
+	p := paginator.Page[model.Task]{CurrentPage: page, PageLimit: limit}
+	p.SelectPages(query)
+
+	return p
+}
+
+func (db *taskConnection) GetTrashedTask(id int64, user_id int64) (task model.Task, err error) {
+	res := db.connection.Unscoped().First(&task, "id = ? AND user_id = ? AND deleted_at IS NOT NULL", id, user_id)
+	if res.Error == gorm.ErrRecordNotFound {
+		return task, apperr.ErrRecordNotFound
+	}
+
+	return task, res.Error
+}
+
+// RestoreTask takes the task out of the trash along with the subtasks deleted with it, and its category if the category is in the trash too.
+// If the parent is still in the trash, the task is restored as a top-level task.
+// ErrCategoryNameDuplicate or ErrTaskTitleDuplicate if a name or a title was taken in the meantime
+func (db *taskConnection) RestoreTask(task model.Task, unique string) error {
+	return db.connection.Transaction(func(tx *gorm.DB) error {
+		category := model.Category{}
+		findCategory := tx.Unscoped().Limit(1).Find(&category, "id = ? AND user_id = ? AND deleted_at IS NOT NULL", task.CategoryID, task.UserID)
+		if findCategory.Error != nil {
+			return findCategory.Error
+		}
+		if findCategory.RowsAffected == 1 {
+			taken, err := categoryNameTaken(tx, category)
+			if err != nil {
+				return err
+			}
+			if taken {
+				return ErrCategoryNameDuplicate
+			}
+		}
+
+		ids, err := descendantIDs(tx, []int64{task.ID}, task.UserID, &task.DeletedAt.Time)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, task.ID)
+
+		taken, err := restoredTitlesTaken(tx, ids, task.UserID, unique)
+		if err != nil {
+			return err
+		}
+		if taken {
+			return ErrTaskTitleDuplicate
+		}
+
+		if findCategory.RowsAffected == 1 {
+			restoreCategory := tx.Unscoped().Model(&model.Category{}).Where("id = ?", category.ID).Update("deleted_at", nil)
+			if isDuplicate(restoreCategory.Error) {
+				return ErrCategoryNameDuplicate
+			}
+			if restoreCategory.Error != nil {
+				return restoreCategory.Error
+			}
+		}
+
+		if task.ParentID != nil {
+			var parents int64
+			if err := tx.Model(&model.Task{}).Where("id = ?", task.ParentID).Count(&parents).Error; err != nil {
+				return err
+			}
+			if parents == 0 {
+				if err := tx.Unscoped().Model(&model.Task{}).Where("id = ?", task.ID).Update("parent_id", nil).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		restore := tx.Unscoped().Model(&model.Task{}).Where("id IN ? AND user_id = ?", ids, task.UserID).Update("deleted_at", nil)
+		if isDuplicate(restore.Error) {
+			return ErrTaskTitleDuplicate
+		}
+
+		return restore.Error
+	})
+}
+
+// restoredTitlesTaken checks whether the title of one of the tasks in the trash is taken by a task out of the trash in the unique scope
+func restoredTitlesTaken(tx *gorm.DB, ids []int64, user_id int64, unique string) (bool, error) {
+	if unique != TitleUniqueUser && unique != TitleUniqueCategory {
+		return false, nil
+	}
+
+	query := tx.Table("tasks AS restored").
+		Joins("JOIN tasks AS alive ON alive.user_id = restored.user_id AND alive.title = restored.title AND alive.deleted_at IS NULL").
+		Where("restored.id IN ? AND restored.user_id = ?", ids, user_id)
+	if unique == TitleUniqueCategory {
+		query.Where("alive.category_id = restored.category_id")
+	}
+
+	var taken []int64
+	if err := query.Limit(1).Pluck("restored.id", &taken).Error; err != nil {
+		return false, err
+	}
+
+	return len(taken) > 0, nil
+}
+
+// PurgeTask deletes the task permanently, its attachments and occurrences are removed by the foreign keys
+// and the stored files by the storage sweep
+func (db *taskConnection) PurgeTask(id int64, user_id int64) error {
+	return db.connection.Unscoped().Where("user_id = ?", user_id).Delete(&model.Task{}, id).Error
+}
+
+// PurgeTrashedTasks empties the user's trash of tasks
+func (db *taskConnection) PurgeTrashedTasks(user_id int64) (purged int64, err error) {
+	delete := db.connection.Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", user_id).Delete(&model.Task{})
+	return delete.RowsAffected, delete.Error
+}
+
+// PurgeExpiredTasks deletes permanently the tasks in the trash since before
+func (db *taskConnection) PurgeExpiredTasks(before time.Time) (purged int64, err error) {
+	delete := db.connection.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", before).Delete(&model.Task{})
+	return delete.RowsAffected, delete.Error
 }
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"go-todolist/utils/telegram"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/tidwall/gjson"
+)
+
+type TelegramEntity interface {
+	// Enabled reports whether a bot token is configured
+	Enabled() bool
+	SendMessage(chatID int64, text string) error
+}
+
+type telegramConnection struct {
+	connection *telegram.Client
+}
+
+func NewTelegramEntity(client *telegram.Client) TelegramEntity {
+	return &telegramConnection{
+		connection: client,
+	}
+}
+
+func (db *telegramConnection) Enabled() bool {
+	return db.connection != nil && db.connection.Token != ""
+}
+
+// SendMessage calls the sendMessage method of the Telegram Bot API
+func (db *telegramConnection) SendMessage(chatID int64, text string) error {
+	if !db.Enabled() {
+		return errors.New("Telegram bot token is not configured.")
+	}
+
+	body, err := json.Marshal(map[string]interface{}{
+		"chat_id": chatID,
+		"text":    text,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := db.connection.BaseURL + "/bot" + db.connection.Token + "/sendMessage"
+	resp, err := db.connection.HTTP.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK || !gjson.GetBytes(content, "ok").Bool() {
+		return errors.New("Telegram sendMessage failed : " + gjson.GetBytes(content, "description").String())
+	}
+
+	return nil
+}
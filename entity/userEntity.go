@@ -1,8 +1,11 @@
 package entity
 
 import (
+	"errors"
 	"go-todolist/model"
+	"go-todolist/utils/apperr"
 	"go-todolist/utils/log"
+	"go-todolist/utils/responses"
 
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
@@ -11,15 +14,27 @@ import (
 //UserEntity is contract what UserEntity can do to db
 type UserEntity interface {
 	//InsertUser is insert user to db
-	InsertUser(user model.User) model.User
+	InsertUser(user model.User) (model.User, error)
 
 	//VerifyCredential is verify user login
 	VerifyCredential(email string) interface{}
 
 	// FindByEmail is find user by email
 	FindByEmail(email string) model.User
+
+	// FindByID is find user by id
+	FindByID(id uint64) model.User
+
+	// UpdateTelegramID is link (or unlink with nil) the Telegram chat of the user
+	UpdateTelegramID(id uint64, telegramID *int64) error
+
+	// UpdateProfile is update the profile settings of the user
+	UpdateProfile(id uint64, values map[string]interface{}) error
 }
 
+// ErrEmailAlreadyExists the email is already registered
+var ErrEmailAlreadyExists = apperr.New(apperr.Conflict, responses.EmailAlreadyExists)
+
 // userConnection is a struct that implements connection to db with gorm
 type userConnection struct {
 	// connection to db with gorm
@@ -33,15 +48,20 @@ func NewUserEntity(db *gorm.DB) UserEntity {
 	}
 }
 
-// InsertUser is insert user to db and return user model to caller function
-func (db *userConnection) InsertUser(user model.User) model.User {
+// InsertUser is insert user to db and return user model to caller function, ErrEmailAlreadyExists if the email is registered meanwhile
+func (db *userConnection) InsertUser(user model.User) (model.User, error) {
 	// hash password
 	user.Password = hashAndSalt([]byte(user.Password))
-	if len(user.Password) > 1 {
-		db.connection.Save(&user)
+	if len(user.Password) < 1 {
+		return user, errors.New("Failed to hash password.")
 	}
 
-	return user
+	save := db.connection.Save(&user)
+	if isDuplicate(save.Error) {
+		return user, ErrEmailAlreadyExists
+	}
+
+	return user, save.Error
 }
 
 // VerifyCredential is verify user credential and return user model to caller function if credential is correct or return nil if credential is incorrect
@@ -68,6 +88,31 @@ func (db *userConnection) FindByEmail(email string) model.User {
 	return user
 }
 
+func (db *userConnection) FindByID(id uint64) model.User {
+	var user model.User
+	db.connection.Where("id = ?", id).Take(&user)
+	return user
+}
+
+// UpdateTelegramID is link the Telegram chat to the user, a chat can only be linked to one user at a time
+func (db *userConnection) UpdateTelegramID(id uint64, telegramID *int64) error {
+	return db.connection.Transaction(func(tx *gorm.DB) error {
+		if telegramID != nil {
+			unlink := tx.Model(&model.User{}).Where("telegram_id = ? AND id <> ?", *telegramID, id).Update("telegram_id", nil)
+			if unlink.Error != nil {
+				return unlink.Error
+			}
+		}
+
+		return tx.Model(&model.User{}).Where("id = ?", id).Update("telegram_id", telegramID).Error
+	})
+}
+
+// UpdateProfile is update the profile settings of the user with a map, so that they can be set to NULL
+func (db *userConnection) UpdateProfile(id uint64, values map[string]interface{}) error {
+	return db.connection.Model(&model.User{}).Where("id = ?", id).Updates(values).Error
+}
+
 // hashAndSalt is hash password and return hashed password
 func hashAndSalt(pwd []byte) string {
 	// hash password
@@ -0,0 +1,163 @@
+package entity
+
+import (
+	"go-todolist/model"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/responses"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WorkflowEntity interface {
+	GetWorkflowStatusList(user_id int64) (statuses []model.WorkflowStatus, err error)
+	GetWorkflowStatus(id int64, user_id int64) (status model.WorkflowStatus, err error)
+	CreateWorkflowStatus(status model.WorkflowStatus) (c model.WorkflowStatus, e error)
+	UpdateWorkflowStatus(id int64, user_id int64, values map[string]interface{}) (c model.WorkflowStatus, e error)
+	DeleteWorkflowStatus(id int64, user_id int64) error
+	CountWorkflowStatusTasks(id int64) (count int64, err error)
+	ReorderWorkflowStatuses(user_id int64, ids []int64) (statuses []model.WorkflowStatus, err error)
+	SetWorkflowTransitions(id int64, to_ids []int64) error
+}
+
+var (
+	ErrWorkflowStatusNameDuplicate = apperr.New(apperr.Conflict, responses.WorkflowStatusNameDuplicate)
+	// A done status and a status not done are always kept
+	ErrWorkflowStatusRequired = apperr.New(apperr.Validation, responses.WorkflowStatusRequired)
+	ErrWorkflowStatusInUse    = apperr.New(apperr.Validation, responses.WorkflowStatusInUse)
+	ErrWorkflowOrderInvalid   = apperr.New(apperr.Validation, responses.WorkflowOrderInvalid)
+)
+
+type workflowConnection struct {
+	connection *gorm.DB
+}
+
+func NewWorkflowEntity(db *gorm.DB) WorkflowEntity {
+	return &workflowConnection{
+		connection: db,
+	}
+}
+
+func orderWorkflowStatuses(db *gorm.DB) *gorm.DB {
+	return db.Preload("Transitions").Order("position, id")
+}
+
+// GetWorkflowStatusList gets the statuses of the user in order, a user without any gets the default ones
+func (db *workflowConnection) GetWorkflowStatusList(user_id int64) (statuses []model.WorkflowStatus, err error) {
+	err = db.connection.Scopes(orderWorkflowStatuses).Where("user_id = ?", user_id).Find(&statuses).Error
+	if err != nil || len(statuses) > 0 {
+		return statuses, err
+	}
+
+	// Another request may create them at the same time, the unique index keeps a single set
+	defaults := model.DefaultWorkflowStatuses(user_id)
+	err = db.connection.Clauses(clause.OnConflict{DoNothing: true}).Create(&defaults).Error
+	if err != nil {
+		return statuses, err
+	}
+
+	err = db.connection.Scopes(orderWorkflowStatuses).Where("user_id = ?", user_id).Find(&statuses).Error
+
+	return statuses, err
+}
+
+func (db *workflowConnection) GetWorkflowStatus(id int64, user_id int64) (status model.WorkflowStatus, err error) {
+	res := db.connection.Preload("Transitions").First(&status, "id = ? AND user_id = ?", id, user_id)
+	if res.Error != nil && res.Error != gorm.ErrRecordNotFound {
+		return status, res.Error
+	}
+
+	return status, nil
+}
+
+// CreateWorkflowStatus appends the status to the end of the board, ErrWorkflowStatusNameDuplicate if the user already has the name
+func (db *workflowConnection) CreateWorkflowStatus(status model.WorkflowStatus) (c model.WorkflowStatus, e error) {
+	err := db.connection.Transaction(func(tx *gorm.DB) error {
+		var last int
+		if err := tx.Model(&model.WorkflowStatus{}).Where("user_id = ?", status.UserID).Select("COALESCE(MAX(position), 0)").Scan(&last).Error; err != nil {
+			return err
+		}
+
+		status.Position = last + 1
+		return tx.Create(&status).Error
+	})
+	if isDuplicate(err) {
+		return status, ErrWorkflowStatusNameDuplicate
+	}
+
+	return status, err
+}
+
+// UpdateWorkflowStatus updates the status with a map, so that is_done can be set to false.
+// The tasks in the status follow is_done, including the ones in the trash
+func (db *workflowConnection) UpdateWorkflowStatus(id int64, user_id int64, values map[string]interface{}) (c model.WorkflowStatus, e error) {
+	err := db.connection.Transaction(func(tx *gorm.DB) error {
+		update := tx.Model(&model.WorkflowStatus{}).Where("id = ? AND user_id = ?", id, user_id).Updates(values)
+		if update.Error != nil {
+			return update.Error
+		}
+
+		if isDone, ok := values["is_done"]; ok {
+			return tx.Unscoped().Model(&model.Task{}).Where("status_id = ? AND user_id = ?", id, user_id).Update("is_complete", isDone).Error
+		}
+
+		return nil
+	})
+	if isDuplicate(err) {
+		return c, ErrWorkflowStatusNameDuplicate
+	}
+	if err != nil {
+		return c, err
+	}
+
+	return db.GetWorkflowStatus(id, user_id)
+}
+
+func (db *workflowConnection) DeleteWorkflowStatus(id int64, user_id int64) error {
+	return db.connection.Where("user_id = ?", user_id).Delete(&model.WorkflowStatus{}, id).Error
+}
+
+// CountWorkflowStatusTasks counts the tasks in the status, including the ones in the trash
+func (db *workflowConnection) CountWorkflowStatusTasks(id int64) (count int64, err error) {
+	err = db.connection.Unscoped().Model(&model.Task{}).Where("status_id = ?", id).Count(&count).Error
+
+	return count, err
+}
+
+// ReorderWorkflowStatuses sets the positions of the statuses in the order of the ids
+func (db *workflowConnection) ReorderWorkflowStatuses(user_id int64, ids []int64) (statuses []model.WorkflowStatus, err error) {
+	err = db.connection.Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			update := tx.Model(&model.WorkflowStatus{}).Where("id = ? AND user_id = ?", id, user_id).Update("position", i+1)
+			if update.Error != nil {
+				return update.Error
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return statuses, err
+	}
+
+	return db.GetWorkflowStatusList(user_id)
+}
+
+// SetWorkflowTransitions replaces the statuses the tasks can move to from the status, none allows any status
+func (db *workflowConnection) SetWorkflowTransitions(id int64, to_ids []int64) error {
+	return db.connection.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("from_status_id = ?", id).Delete(&model.WorkflowTransition{}).Error; err != nil {
+			return err
+		}
+		if len(to_ids) == 0 {
+			return nil
+		}
+
+		transitions := make([]model.WorkflowTransition, len(to_ids))
+		for i, to := range to_ids {
+			transitions[i] = model.WorkflowTransition{FromStatusID: id, ToStatusID: to}
+		}
+
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&transitions).Error
+	})
+}
@@ -2,6 +2,10 @@ package main
 
 import (
 	"go-todolist/router"
+	"os"
+
+	// The IANA timezones of the users, the alpine image has no zoneinfo
+	_ "time/tzdata"
 )
 
 // @title Gin swagger
@@ -12,5 +16,17 @@ import (
 // @BasePath /api/v1
 // schemes http
 func main() {
+	// go run . gc [-dry-run] [-grace 24h]
+	if len(os.Args) > 1 && os.Args[1] == "gc" {
+		router.RunStorageGC(os.Args[2:])
+		return
+	}
+
+	// go run . title-scope
+	if len(os.Args) > 1 && os.Args[1] == "title-scope" {
+		router.RunTitleScope()
+		return
+	}
+
 	router.SetupRouter()
 }
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"go-todolist/controller"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/log"
+	"go-todolist/utils/responses"
+	"go-todolist/utils/validation"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errorStatus is the HTTP status of each kind of domain error
+var errorStatus = map[apperr.Kind]int{
+	apperr.Internal:     http.StatusInternalServerError,
+	apperr.NotFound:     http.StatusNotFound,
+	apperr.Conflict:     http.StatusConflict,
+	apperr.Validation:   http.StatusBadRequest,
+	apperr.Unauthorized: http.StatusUnauthorized,
+	apperr.Forbidden:    http.StatusForbidden,
+	apperr.Upstream:     http.StatusBadGateway,
+}
+
+// ErrorHandler writes the response of the error a controller added with c.Error,
+// the domain errors get the status of their kind and any other error is a 500.
+// The validation errors of the binding are listed by field
+func ErrorHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		status := http.StatusInternalServerError
+		var response responses.Response
+		if appErr, ok := apperr.As(err); ok {
+			status = errorStatus[appErr.Kind]
+			if appErr.Code != 0 {
+				response = responses.ErrorsResponseByCode(controller.GetLocale(c), status, "Failed to process request", appErr.Code, nil)
+			} else if fields, ok := validation.FromError(controller.GetLocale(c), appErr.Err); ok {
+				response = responses.FieldErrorsResponse(controller.GetLocale(c), status, "Failed to process request", fields, nil)
+			} else {
+				response = responses.ErrorsResponse(controller.GetLocale(c), status, "Failed to process request", appErr.Error(), nil)
+			}
+		} else {
+			response = responses.ErrorsResponse(controller.GetLocale(c), status, "Failed to process request", err.Error(), nil)
+		}
+
+		if status >= http.StatusInternalServerError {
+			log.Error(c.Request.Method + " " + c.FullPath() + " : " + err.Error())
+		}
+
+		c.AbortWithStatusJSON(status, response)
+	}
+}
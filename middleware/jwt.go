@@ -19,11 +19,11 @@ func AuthorizeJWT(s services.JWTService) gin.HandlerFunc {
 		authHeader := controller.ParseToken(c)
 		switch authHeader {
 		case "No token found":
-			response := responses.ErrorsResponseByCode(http.StatusBadRequest, "Failed to process request", responses.NoTokenFound, nil)
+			response := responses.ErrorsResponseByCode(controller.GetLocale(c), http.StatusBadRequest, "Failed to process request", responses.NoTokenFound, nil)
 			c.AbortWithStatusJSON(http.StatusBadRequest, response)
 			return
 		case "Bearer token not in proper format":
-			response := responses.ErrorsResponseByCode(http.StatusBadRequest, "Failed to process request", responses.BearerTokenNotInProperFormat, nil)
+			response := responses.ErrorsResponseByCode(controller.GetLocale(c), http.StatusBadRequest, "Failed to process request", responses.BearerTokenNotInProperFormat, nil)
 			c.AbortWithStatusJSON(http.StatusBadRequest, response)
 			return
 		}
@@ -31,33 +31,45 @@ func AuthorizeJWT(s services.JWTService) gin.HandlerFunc {
 		// Validate the token
 		token, err := s.ValidateToken(authHeader)
 		if err != nil {
-			response := responses.ErrorsResponse(http.StatusUnauthorized, "Token is not valid", err.Error(), nil)
+			response := responses.ErrorsResponse(controller.GetLocale(c), http.StatusUnauthorized, "Token is not valid", err.Error(), nil)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
+			return
+		}
+
+		// Get the claims of the token
+		claims := token.Claims.(jwt.MapClaims)
+		// output the user_id
+		log.Println("Claim[user_id]: ", claims["user_id"])
+		// output the issuer
+		log.Println("Claim[issuer] :", claims["iss"])
+
+		// JSON numbers are decoded as float64 in jwt.MapClaims
+		userID, ok := claims["user_id"].(float64)
+		if !ok || userID < 1 {
+			response := responses.ErrorsResponseByCode(controller.GetLocale(c), http.StatusUnauthorized, "Token is not valid", responses.TokenInvalid, nil)
 			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
 			return
-		} else {
-			// Get the claims of the token
-			claims := token.Claims.(jwt.MapClaims)
-			// output the user_id
-			log.Println("Claim[user_id]: ", claims["user_id"])
-			// output the issuer
-			log.Println("Claim[issuer] :", claims["iss"])
 		}
 
 		// whitelist for token
 		redisToken := s.AuthJWT(authHeader)
 		if len(redisToken) < 1 {
-			response := responses.ErrorsResponseByCode(http.StatusUnauthorized, "Token is not valid", responses.TokenDoesNotExistOrExpired, nil)
+			response := responses.ErrorsResponseByCode(controller.GetLocale(c), http.StatusUnauthorized, "Token is not valid", responses.TokenDoesNotExistOrExpired, nil)
 			c.AbortWithStatusJSON(http.StatusUnauthorized, response)
 			return
 		}
 
 		// Check if the token is the same as redis
 		if authHeader != redisToken {
-			response := responses.ErrorsResponseByCode(http.StatusBadRequest, "Failed to process request", responses.TokenInvalid, nil)
+			response := responses.ErrorsResponseByCode(controller.GetLocale(c), http.StatusBadRequest, "Failed to process request", responses.TokenInvalid, nil)
 			c.AbortWithStatusJSON(http.StatusBadRequest, response)
 			return
 		}
 
+		// Share the authenticated user ID and session ID with the following handlers
+		c.Set(controller.AuthUserIDKey, int64(userID))
+		c.Set(controller.AuthSessionIDKey, claims["jti"])
+
 		c.Next()
 	}
 }
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"go-todolist/controller"
+	"go-todolist/entity"
+	"go-todolist/utils/civilDatetime"
+	"go-todolist/utils/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Locale negotiates the locale of the response messages from the Accept-Language header
+func Locale() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		controller.SetLocale(c, i18n.Resolve(nil, c.GetHeader("Accept-Language")))
+		c.Next()
+	}
+}
+
+// UserPreferences uses the preferred locale and the timezone of the authenticated user, it runs after AuthorizeJWT
+func UserPreferences(userEntity entity.UserEntity) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := userEntity.FindByID(uint64(controller.GetAuthUserID(c)))
+		if user.Locale != nil {
+			controller.SetLocale(c, i18n.Resolve(user.Locale, c.GetHeader("Accept-Language")))
+		}
+		if user.Timezone != nil {
+			if loc, ok := civilDatetime.LoadLocation(*user.Timezone); ok {
+				c.Set(controller.TimezoneKey, loc)
+			}
+		}
+		c.Next()
+	}
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"go-todolist/controller"
 	"go-todolist/entity"
 	"go-todolist/utils/log"
 	"go-todolist/utils/responses"
@@ -32,7 +33,7 @@ func (r *rateLimiterMiddleware) RateLimiter(limit int, expireAt int64) gin.Handl
 
 		getLimiter, _ := r.redisEntity.GetInt(ip)
 		if getLimiter == limit {
-			response := responses.ErrorsResponseByCode(http.StatusTooManyRequests, "Failed to get IP rate limiter", responses.TooManyRequests, nil)
+			response := responses.ErrorsResponseByCode(controller.GetLocale(c), http.StatusTooManyRequests, "Failed to get IP rate limiter", responses.TooManyRequests, nil)
 			c.AbortWithStatusJSON(http.StatusTooManyRequests, response)
 			return
 		}
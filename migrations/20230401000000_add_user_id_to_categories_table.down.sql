@@ -0,0 +1,4 @@
+ALTER TABLE `categories` DROP FOREIGN KEY `categories_user_id_foreign`;
+DROP INDEX `uidx_user_id_name` ON `categories`;
+ALTER TABLE `categories` DROP COLUMN `user_id`;
+create unique index `uidx_name` on `categories` (`name`) using BTREE;
@@ -0,0 +1,5 @@
+ALTER TABLE `categories` ADD COLUMN `user_id` bigint NULL DEFAULT NULL COMMENT '用戶ID(NULL:系統類別)' AFTER `id`;
+
+DROP INDEX `uidx_name` ON `categories`;
+create unique index `uidx_user_id_name` on `categories` (`user_id`, `name`) using BTREE;
+ALTER TABLE `categories` ADD CONSTRAINT `categories_user_id_foreign` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+ALTER TABLE `tasks`
+  ADD COLUMN `rrule`      varchar(255)  NULL      DEFAULT NULL  COMMENT '重複規則(RFC 5545 RRULE)'  AFTER `is_specify_time`,
+  ADD COLUMN `occurrence` int           NOT NULL  DEFAULT 1     COMMENT '重複次數(第幾次)'          AFTER `rrule`;
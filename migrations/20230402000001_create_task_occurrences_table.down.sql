@@ -0,0 +1,2 @@
+ALTER TABLE `task_occurrences` DROP FOREIGN KEY `tasks_task_id_foreign`;
+DROP TABLE IF EXISTS `task_occurrences`;
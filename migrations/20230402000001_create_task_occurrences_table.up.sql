@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS `task_occurrences`;
+CREATE TABLE IF NOT EXISTS `task_occurrences` (
+  `id`                bigint        NOT NULL  AUTO_INCREMENT  PRIMARY KEY,
+  `task_id`           bigint        NOT NULL,
+  `occurrence`        int           NOT NULL  DEFAULT 1       COMMENT '重複次數(第幾次)',
+  `specify_datetime`  datetime      NULL      DEFAULT NULL    COMMENT '該次指定日期時間(Y-m-d H:i:s)',
+  `completed_at`      timestamp     NOT NULL  DEFAULT NOW()   COMMENT '完成時間',
+  `created_at`        timestamp     NOT NULL  DEFAULT NOW()   COMMENT '新增時間'
+);
+
+create index `idx_task_id_occurrence` on `task_occurrences` (`task_id`, `occurrence` desc) using BTREE;
+ALTER TABLE `task_occurrences` ADD CONSTRAINT `tasks_task_id_foreign` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`) ON DELETE CASCADE;
@@ -0,0 +1,11 @@
+ALTER TABLE `tasks`
+  ADD COLUMN `img`      varchar(100)  NULL                    COMMENT '影像名稱'    AFTER `url`,
+  ADD COLUMN `img_link` varchar(255)  NULL                    COMMENT '影像路徑'    AFTER `img`,
+  ADD COLUMN `img_uuid` varchar(128)  NULL  DEFAULT NULL      COMMENT '影像唯一碼'  AFTER `img_link`;
+
+-- Only the first attachment of a task can be kept as its image
+UPDATE `tasks` JOIN `task_attachments` ON `task_attachments`.`id` = (SELECT MIN(`id`) FROM `task_attachments` WHERE `task_id` = `tasks`.`id`)
+SET `tasks`.`img` = LEFT(`task_attachments`.`filename`, 100), `tasks`.`img_uuid` = `task_attachments`.`uuid`;
+
+ALTER TABLE `task_attachments` DROP FOREIGN KEY `tasks_task_id_attachments_foreign`;
+DROP TABLE IF EXISTS `task_attachments`;
@@ -0,0 +1,20 @@
+DROP TABLE IF EXISTS `task_attachments`;
+CREATE TABLE IF NOT EXISTS `task_attachments` (
+  `id`                bigint        NOT NULL  AUTO_INCREMENT  PRIMARY KEY,
+  `task_id`           bigint        NOT NULL,
+  `filename`          varchar(255)  NOT NULL  DEFAULT ''      COMMENT '檔案名稱',
+  `uuid`              varchar(128)  NOT NULL  DEFAULT ''      COMMENT '檔案唯一碼(物件路徑 uuid/filename)',
+  `content_type`      varchar(100)  NOT NULL  DEFAULT ''      COMMENT '檔案類型',
+  `size`              bigint        NOT NULL  DEFAULT 0       COMMENT '檔案大小(Byte)',
+  `created_at`        timestamp     NOT NULL  DEFAULT NOW()   COMMENT '新增時間',
+  `updated_at`        timestamp     NOT NULL  DEFAULT NOW()   COMMENT '更新時間'
+);
+
+create index `idx_task_id` on `task_attachments` (`task_id`) using BTREE;
+ALTER TABLE `task_attachments` ADD CONSTRAINT `tasks_task_id_attachments_foreign` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`) ON DELETE CASCADE;
+
+-- Move the single image of the tasks into the attachments
+INSERT INTO `task_attachments` (`task_id`, `filename`, `uuid`, `created_at`, `updated_at`)
+SELECT `id`, `img`, `img_uuid`, `updated_at`, `updated_at` FROM `tasks` WHERE `img` IS NOT NULL AND `img` != '' AND `img_uuid` IS NOT NULL;
+
+ALTER TABLE `tasks` DROP COLUMN `img`, DROP COLUMN `img_link`, DROP COLUMN `img_uuid`;
@@ -0,0 +1,2 @@
+ALTER TABLE `task_attachments`
+  ADD COLUMN `thumbnails` varchar(50) NOT NULL DEFAULT '' COMMENT '縮圖尺寸(以逗號分隔，例如 128,512)' AFTER `size`;
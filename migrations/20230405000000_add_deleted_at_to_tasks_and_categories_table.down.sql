@@ -0,0 +1,23 @@
+-- The rows in the trash are restored, the ones whose title (name) is repeated are renamed with their id
+-- so that the unique indexes can be created again, e.g. `Buy milk (42)`
+UPDATE `tasks` t
+  JOIN (SELECT `title` FROM `tasks` GROUP BY `title` HAVING COUNT(*) > 1) repeated ON repeated.`title` = t.`title`
+  SET t.`title` = CONCAT(LEFT(t.`title`, 100 - CHAR_LENGTH(CONCAT(' (', t.`id`, ')'))), ' (', t.`id`, ')')
+  WHERE t.`deleted_at` IS NOT NULL;
+
+UPDATE `categories` c
+  JOIN (SELECT `user_id`, `name` FROM `categories` GROUP BY `user_id`, `name` HAVING COUNT(*) > 1) repeated
+    ON repeated.`user_id` = c.`user_id` AND repeated.`name` = c.`name`
+  SET c.`name` = CONCAT(LEFT(c.`name`, 100 - CHAR_LENGTH(CONCAT(' (', c.`id`, ')'))), ' (', c.`id`, ')')
+  WHERE c.`deleted_at` IS NOT NULL;
+
+DROP INDEX `idx_deleted_at` ON `tasks`;
+DROP INDEX `unique_title` ON `tasks`;
+create unique index `unique_title` on `tasks` (`title`) using BTREE;
+
+DROP INDEX `idx_deleted_at` ON `categories`;
+DROP INDEX `uidx_user_id_name` ON `categories`;
+create unique index `uidx_user_id_name` on `categories` (`user_id`, `name`) using BTREE;
+
+ALTER TABLE `tasks` DROP COLUMN `is_alive`, DROP COLUMN `deleted_at`;
+ALTER TABLE `categories` DROP COLUMN `is_alive`, DROP COLUMN `deleted_at`;
@@ -0,0 +1,17 @@
+-- `is_alive` is 1 for the rows not in the trash and NULL for the deleted ones,
+-- so that the unique indexes ignore the rows in the trash
+ALTER TABLE `categories`
+  ADD COLUMN `deleted_at` timestamp NULL    DEFAULT NULL  COMMENT '刪除時間(垃圾桶)'  AFTER `updated_at`,
+  ADD COLUMN `is_alive`   tinyint   AS (IF(`deleted_at` IS NULL, 1, NULL)) VIRTUAL  COMMENT '未刪除(1)'  AFTER `deleted_at`;
+
+ALTER TABLE `tasks`
+  ADD COLUMN `deleted_at` timestamp NULL    DEFAULT NULL  COMMENT '刪除時間(垃圾桶)'  AFTER `updated_at`,
+  ADD COLUMN `is_alive`   tinyint   AS (IF(`deleted_at` IS NULL, 1, NULL)) VIRTUAL  COMMENT '未刪除(1)'  AFTER `deleted_at`;
+
+DROP INDEX `uidx_user_id_name` ON `categories`;
+create unique index `uidx_user_id_name` on `categories` (`user_id`, `name`, `is_alive`) using BTREE;
+create index `idx_deleted_at` on `categories` (`deleted_at`) using BTREE;
+
+DROP INDEX `unique_title` ON `tasks`;
+create unique index `unique_title` on `tasks` (`title`, `is_alive`) using BTREE;
+create index `idx_deleted_at` on `tasks` (`deleted_at`) using BTREE;
@@ -0,0 +1 @@
+DROP INDEX `ftidx_title_note_url` ON `tasks`;
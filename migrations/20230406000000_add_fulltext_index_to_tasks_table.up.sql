@@ -0,0 +1,4 @@
+-- The ngram parser tokenizes CJK text as well, the stopwords are disabled because
+-- it drops every token containing one (e.g. "a"), the setting is kept by the index
+SET SESSION innodb_ft_enable_stopword = OFF;
+create fulltext index `ftidx_title_note_url` on `tasks` (`title`, `note`, `url`) with parser ngram;
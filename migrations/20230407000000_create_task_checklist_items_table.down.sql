@@ -0,0 +1,5 @@
+ALTER TABLE `tasks` DROP FOREIGN KEY `tasks_parent_id_foreign`;
+DROP INDEX `idx_parent_id` ON `tasks`;
+ALTER TABLE `tasks` DROP COLUMN `parent_id`;
+
+DROP TABLE IF EXISTS `task_checklist_items`;
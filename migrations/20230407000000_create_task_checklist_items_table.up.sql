@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS `task_checklist_items`;
+CREATE TABLE IF NOT EXISTS `task_checklist_items` (
+  `id`                bigint        NOT NULL  AUTO_INCREMENT  PRIMARY KEY,
+  `task_id`           bigint        NOT NULL,
+  `title`             varchar(255)  NOT NULL  DEFAULT ''      COMMENT '項目標題',
+  `is_done`           bool          NOT NULL  DEFAULT false   COMMENT '是否完成',
+  `position`          int           NOT NULL  DEFAULT 0       COMMENT '排序(由小到大)',
+  `created_at`        timestamp     NOT NULL  DEFAULT NOW()   COMMENT '新增時間',
+  `updated_at`        timestamp     NOT NULL  DEFAULT NOW()   COMMENT '更新時間'
+);
+
+create index `idx_task_id_position` on `task_checklist_items` (`task_id`, `position`) using BTREE;
+ALTER TABLE `task_checklist_items` ADD CONSTRAINT `tasks_task_id_checklist_items_foreign` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`) ON DELETE CASCADE;
+
+-- Subtasks, a purged parent leaves its children as top-level tasks
+ALTER TABLE `tasks` ADD COLUMN `parent_id` bigint NULL DEFAULT NULL COMMENT '父任務ID(NULL:最上層任務)' AFTER `category_id`;
+create index `idx_parent_id` on `tasks` (`parent_id`) using BTREE;
+ALTER TABLE `tasks` ADD CONSTRAINT `tasks_parent_id_foreign` FOREIGN KEY (`parent_id`) REFERENCES `tasks`(`id`) ON DELETE SET NULL;
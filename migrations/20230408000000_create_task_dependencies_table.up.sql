@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS `task_dependencies`;
+CREATE TABLE IF NOT EXISTS `task_dependencies` (
+  `task_id`           bigint        NOT NULL                  COMMENT '被阻擋的任務ID',
+  `blocked_by_id`     bigint        NOT NULL                  COMMENT '需先完成的任務ID',
+  `created_at`        timestamp     NOT NULL  DEFAULT NOW()   COMMENT '新增時間',
+  PRIMARY KEY (`task_id`, `blocked_by_id`)
+);
+
+create index `idx_blocked_by_id` on `task_dependencies` (`blocked_by_id`) using BTREE;
+ALTER TABLE `task_dependencies` ADD CONSTRAINT `tasks_task_id_dependencies_foreign` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`) ON DELETE CASCADE;
+ALTER TABLE `task_dependencies` ADD CONSTRAINT `tasks_blocked_by_id_dependencies_foreign` FOREIGN KEY (`blocked_by_id`) REFERENCES `tasks`(`id`) ON DELETE CASCADE;
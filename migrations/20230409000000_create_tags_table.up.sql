@@ -0,0 +1,24 @@
+DROP TABLE IF EXISTS `tags`;
+CREATE TABLE IF NOT EXISTS `tags` (
+  `id`                bigint        NOT NULL  AUTO_INCREMENT  PRIMARY KEY,
+  `user_id`           bigint        NOT NULL,
+  `name`              varchar(50)   NOT NULL  DEFAULT ''        COMMENT '標籤名稱',
+  `color`             varchar(7)    NOT NULL  DEFAULT '#9e9e9e' COMMENT '標籤顏色(#rrggbb)',
+  `created_at`        timestamp     NOT NULL  DEFAULT NOW()     COMMENT '新增時間',
+  `updated_at`        timestamp     NOT NULL  DEFAULT NOW()     COMMENT '更新時間'
+);
+
+create unique index `uidx_user_id_name` on `tags` (`user_id`, `name`) using BTREE;
+ALTER TABLE `tags` ADD CONSTRAINT `tags_user_id_foreign` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE;
+
+DROP TABLE IF EXISTS `task_tags`;
+CREATE TABLE IF NOT EXISTS `task_tags` (
+  `task_id`           bigint        NOT NULL,
+  `tag_id`            bigint        NOT NULL,
+  `created_at`        timestamp     NOT NULL  DEFAULT NOW()     COMMENT '新增時間',
+  PRIMARY KEY (`task_id`, `tag_id`)
+);
+
+create index `idx_tag_id` on `task_tags` (`tag_id`) using BTREE;
+ALTER TABLE `task_tags` ADD CONSTRAINT `tasks_task_id_tags_foreign` FOREIGN KEY (`task_id`) REFERENCES `tasks`(`id`) ON DELETE CASCADE;
+ALTER TABLE `task_tags` ADD CONSTRAINT `tags_tag_id_tasks_foreign` FOREIGN KEY (`tag_id`) REFERENCES `tags`(`id`) ON DELETE CASCADE;
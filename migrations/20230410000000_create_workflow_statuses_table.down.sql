@@ -0,0 +1,6 @@
+ALTER TABLE `tasks` DROP FOREIGN KEY `tasks_status_id_foreign`;
+DROP INDEX `idx_status_id_position` ON `tasks`;
+ALTER TABLE `tasks` DROP COLUMN `status_id`, DROP COLUMN `position`;
+
+DROP TABLE IF EXISTS `workflow_transitions`;
+DROP TABLE IF EXISTS `workflow_statuses`;
@@ -0,0 +1,41 @@
+DROP TABLE IF EXISTS `workflow_statuses`;
+CREATE TABLE IF NOT EXISTS `workflow_statuses` (
+  `id`                bigint        NOT NULL  AUTO_INCREMENT  PRIMARY KEY,
+  `user_id`           bigint        NOT NULL,
+  `name`              varchar(50)   NOT NULL  DEFAULT ''      COMMENT '狀態名稱(看板欄位)',
+  `position`          int           NOT NULL  DEFAULT 0       COMMENT '排序(由小到大)',
+  `is_done`           bool          NOT NULL  DEFAULT false   COMMENT '是否為完成狀態',
+  `created_at`        timestamp     NOT NULL  DEFAULT NOW()   COMMENT '新增時間',
+  `updated_at`        timestamp     NOT NULL  DEFAULT NOW()   COMMENT '更新時間'
+);
+
+create unique index `uidx_user_id_name` on `workflow_statuses` (`user_id`, `name`) using BTREE;
+ALTER TABLE `workflow_statuses` ADD CONSTRAINT `workflow_statuses_user_id_foreign` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`) ON DELETE CASCADE;
+
+DROP TABLE IF EXISTS `workflow_transitions`;
+CREATE TABLE IF NOT EXISTS `workflow_transitions` (
+  `from_status_id`    bigint        NOT NULL                  COMMENT '原狀態ID',
+  `to_status_id`      bigint        NOT NULL                  COMMENT '可移至的狀態ID',
+  PRIMARY KEY (`from_status_id`, `to_status_id`)
+);
+
+create index `idx_to_status_id` on `workflow_transitions` (`to_status_id`) using BTREE;
+ALTER TABLE `workflow_transitions` ADD CONSTRAINT `workflow_statuses_from_status_id_foreign` FOREIGN KEY (`from_status_id`) REFERENCES `workflow_statuses`(`id`) ON DELETE CASCADE;
+ALTER TABLE `workflow_transitions` ADD CONSTRAINT `workflow_statuses_to_status_id_foreign` FOREIGN KEY (`to_status_id`) REFERENCES `workflow_statuses`(`id`) ON DELETE CASCADE;
+
+-- Default statuses of the existing users, the new users get them on first use
+INSERT INTO `workflow_statuses` (`user_id`, `name`, `position`, `is_done`)
+SELECT `id`, 'todo', 1, false FROM `users`
+UNION ALL SELECT `id`, 'doing', 2, false FROM `users`
+UNION ALL SELECT `id`, 'done', 3, true FROM `users`;
+
+ALTER TABLE `tasks`
+  ADD COLUMN `status_id` bigint NULL DEFAULT NULL COMMENT '狀態ID(看板欄位)'       AFTER `parent_id`,
+  ADD COLUMN `position`  int    NOT NULL DEFAULT 0  COMMENT '欄位內排序(由小到大)' AFTER `status_id`;
+
+-- is_complete is derived from the status from now on
+UPDATE `tasks` JOIN `workflow_statuses` ON `workflow_statuses`.`user_id` = `tasks`.`user_id` AND `workflow_statuses`.`name` = IF(`tasks`.`is_complete`, 'done', 'todo')
+SET `tasks`.`status_id` = `workflow_statuses`.`id`, `tasks`.`position` = `tasks`.`id`;
+
+create index `idx_status_id_position` on `tasks` (`status_id`, `position`) using BTREE;
+ALTER TABLE `tasks` ADD CONSTRAINT `tasks_status_id_foreign` FOREIGN KEY (`status_id`) REFERENCES `workflow_statuses`(`id`) ON DELETE SET NULL;
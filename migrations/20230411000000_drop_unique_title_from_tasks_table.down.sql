@@ -0,0 +1,3 @@
+-- The titles repeated among the users have to be renamed before the global unique index is restored
+DROP INDEX `idx_user_id_category_id_title` ON `tasks`;
+create unique index `unique_title` on `tasks` (`title`, `is_alive`) using BTREE;
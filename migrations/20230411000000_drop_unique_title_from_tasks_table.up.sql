@@ -0,0 +1,4 @@
+-- The uniqueness of the titles is checked per user (or per user and category) by the application,
+-- depending on TASK_TITLE_UNIQUE, so the global unique index is replaced by a plain one
+DROP INDEX `unique_title` ON `tasks`;
+create index `idx_user_id_category_id_title` on `tasks` (`user_id`, `category_id`, `title`(15)) using BTREE;
@@ -0,0 +1,2 @@
+DROP INDEX `uidx_user_id_title_scope_title` ON `tasks`;
+ALTER TABLE `tasks` DROP COLUMN `title_scope`;
@@ -0,0 +1,6 @@
+-- `title_scope` is the uniqueness scope of the title set by the application from TASK_TITLE_UNIQUE:
+-- 0 for the tasks of the user, the category id for the tasks of the user in the category and NULL without uniqueness.
+-- It is filled by `go run . title-scope`, so that the unique index enforces the titles even with concurrent requests
+ALTER TABLE `tasks` ADD COLUMN `title_scope` bigint NULL DEFAULT NULL COMMENT '標題唯一範圍' AFTER `title`;
+
+create unique index `uidx_user_id_title_scope_title` on `tasks` (`user_id`, `title_scope`, `title`, `is_alive`) using BTREE;
@@ -0,0 +1,2 @@
+-- Preferred locale of the API messages (en, zh-TW), NULL negotiates it from Accept-Language
+ALTER TABLE `users` ADD COLUMN `locale` varchar(10) NULL DEFAULT NULL COMMENT '語系' AFTER `telegram_id`;
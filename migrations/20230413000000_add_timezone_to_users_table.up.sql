@@ -0,0 +1,2 @@
+-- IANA timezone of the datetimes of the user (e.g. Asia/Taipei), NULL uses DEFAULT_TIMEZONE
+ALTER TABLE `users` ADD COLUMN `timezone` varchar(64) NULL DEFAULT NULL COMMENT '時區(IANA)' AFTER `locale`;
@@ -0,0 +1,2 @@
+-- The times of the tasks without a specified time were not used, they are not restored
+DO 0;
@@ -0,0 +1,6 @@
+-- A task without a specified time keeps its date at 00:00 UTC, so that it is due on the same date in every timezone
+UPDATE `tasks` SET `is_specify_time` = false WHERE `is_specify_time` IS NULL;
+UPDATE `tasks` SET `specify_datetime` = DATE(`specify_datetime`) WHERE `is_specify_time` = false AND `specify_datetime` IS NOT NULL;
+UPDATE `task_occurrences` JOIN `tasks` ON `tasks`.`id` = `task_occurrences`.`task_id`
+  SET `task_occurrences`.`specify_datetime` = DATE(`task_occurrences`.`specify_datetime`)
+  WHERE `tasks`.`is_specify_time` = false AND `task_occurrences`.`specify_datetime` IS NOT NULL;
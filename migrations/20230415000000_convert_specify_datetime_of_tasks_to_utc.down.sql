@@ -0,0 +1,10 @@
+-- Set @source_time_zone to the same timezone as the up migration
+SET @source_time_zone = '+00:00';
+
+UPDATE `tasks` SET `specify_datetime` = CONVERT_TZ(`specify_datetime`, '+00:00', @source_time_zone)
+  WHERE `is_specify_time` = true AND `specify_datetime` IS NOT NULL
+  AND CONVERT_TZ(`specify_datetime`, '+00:00', @source_time_zone) IS NOT NULL;
+UPDATE `task_occurrences` JOIN `tasks` ON `tasks`.`id` = `task_occurrences`.`task_id`
+  SET `task_occurrences`.`specify_datetime` = CONVERT_TZ(`task_occurrences`.`specify_datetime`, '+00:00', @source_time_zone)
+  WHERE `tasks`.`is_specify_time` = true AND `task_occurrences`.`specify_datetime` IS NOT NULL
+  AND CONVERT_TZ(`task_occurrences`.`specify_datetime`, '+00:00', @source_time_zone) IS NOT NULL;
@@ -0,0 +1,13 @@
+-- The datetimes of the tasks with a specified time were written in the timezone of the application server (loc=Local),
+-- they are stored in UTC from now on. Set @source_time_zone to that timezone before running the migration if it was not UTC:
+-- an offset (e.g. '+08:00'), or a named timezone (e.g. 'Asia/Taipei') once the MySQL time zone tables are loaded.
+-- The tasks without a specified time keep their date (see 20230413000001)
+SET @source_time_zone = '+00:00';
+
+UPDATE `tasks` SET `specify_datetime` = CONVERT_TZ(`specify_datetime`, @source_time_zone, '+00:00')
+  WHERE `is_specify_time` = true AND `specify_datetime` IS NOT NULL
+  AND CONVERT_TZ(`specify_datetime`, @source_time_zone, '+00:00') IS NOT NULL;
+UPDATE `task_occurrences` JOIN `tasks` ON `tasks`.`id` = `task_occurrences`.`task_id`
+  SET `task_occurrences`.`specify_datetime` = CONVERT_TZ(`task_occurrences`.`specify_datetime`, @source_time_zone, '+00:00')
+  WHERE `tasks`.`is_specify_time` = true AND `task_occurrences`.`specify_datetime` IS NOT NULL
+  AND CONVERT_TZ(`task_occurrences`.`specify_datetime`, @source_time_zone, '+00:00') IS NOT NULL;
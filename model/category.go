@@ -1,10 +1,23 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Category struct {
-	ID        int64      `json:"id"`
+	ID int64 `json:"id"`
+	// NULL means a system category shared by every user (read-only)
+	UserID    *int64     `json:"user_id"`
 	Name      string     `json:"name"`
 	CreatedAt *time.Time `json:"created_at"`
 	UpdatedAt *time.Time `json:"updated_at"`
+	// Set when the category is in the trash
+	DeletedAt gorm.DeletedAt `json:"deleted_at" swaggertype:"string" format:"date-time"`
+}
+
+// IsSystem reports whether the category is a shared system category
+func (c Category) IsSystem() bool {
+	return c.UserID == nil
 }
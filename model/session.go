@@ -0,0 +1,16 @@
+package model
+
+import "time"
+
+// Session is a signed in device, stored in redis and keyed by the token jti
+type Session struct {
+	ID        string    `json:"id"`
+	UserID    uint64    `json:"user_id"`
+	Token     string    `json:"token,omitempty"`
+	Device    string    `json:"device"`
+	IP        string    `json:"ip"`
+	UserAgent string    `json:"user_agent"`
+	CreatedAt time.Time `json:"created_at"`
+	LastSeen  time.Time `json:"last_seen"`
+	Current   bool      `json:"current"`
+}
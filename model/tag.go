@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// Default color of the tags
+const TagColorDefault = "#9e9e9e"
+
+// Tag is a label of the user, a task can have several tags (task_tags)
+type Tag struct {
+	ID        int64      `json:"id"`
+	UserID    int64      `json:"user_id"`
+	Name      string     `json:"name"`
+	Color     string     `json:"color"` // #rrggbb
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+// TaskTag is a tag attached to a task
+type TaskTag struct {
+	TaskID    int64      `gorm:"primaryKey" json:"task_id"`
+	TagID     int64      `gorm:"primaryKey" json:"tag_id"`
+	CreatedAt *time.Time `json:"created_at"`
+}
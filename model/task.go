@@ -1,24 +1,183 @@
 package model
 
 import (
+	"go-todolist/utils/civilDatetime"
+	"go-todolist/utils/search"
 	"time"
+
+	"gorm.io/gorm"
+)
+
+// tasks.is_notify
+const (
+	NotifyNone     int8 = 0
+	NotifyTelegram int8 = 1
+	NotifyWeb      int8 = 2
 )
 
 type Task struct {
-	ID              int64      `json:"id"`
-	UserID          int64      `json:"user_id"`
-	CategoryID      int64      `json:"category_id"`
-	Category        Category   `gorm:"foreignkey:CategoryID;references:ID" json:"category"`
-	Title           string     `json:"title"`
-	Note            string     `json:"note"`
-	Url             string     `json:"url"`
-	Img             string     `json:"img"`
-	ImgLink         string     `json:"img_link"`
-	ImgUuid         string     `json:"img_uuid"`
-	SpecifyDatetime *time.Time `json:"specify_datetime"`
-	IsSpecifyTime   bool       `json:"is_specify_time"`
-	Priority        int8       `json:"priority"`
-	IsComplete      bool       `json:"is_complete"`
-	CreatedAt       *time.Time `json:"created_at"`
-	UpdatedAt       *time.Time `json:"updated_at"`
+	ID              int64               `json:"id"`
+	UserID          int64               `json:"user_id"`
+	CategoryID      int64               `json:"category_id"`
+	Category        Category            `gorm:"foreignkey:CategoryID;references:ID" json:"category"`
+	Tags            []Tag               `gorm:"many2many:task_tags" json:"tags"`
+	ParentID        *int64              `json:"parent_id"` // NULL for a top-level task
+	StatusID        *int64              `json:"status_id"`
+	Status          WorkflowStatus      `gorm:"foreignKey:StatusID" json:"status"`
+	Position        int                 `json:"position"` // Order in the status column
+	Title           string              `json:"title"`
+	TitleScope      *int64              `json:"-"` // Uniqueness scope of the title, see entity.TitleScope
+	Note            string              `json:"note"`
+	Url             string              `json:"url"`
+	Attachments     []TaskAttachment    `gorm:"foreignKey:TaskID" json:"attachments"`
+	Checklist       []TaskChecklistItem `gorm:"foreignKey:TaskID" json:"checklist"`
+	Children        []Task              `gorm:"foreignKey:ParentID" json:"children,omitempty"`                                                            // Subtasks
+	BlockedBy       []Task              `gorm:"many2many:task_dependencies;joinForeignKey:TaskID;joinReferences:BlockedByID" json:"blocked_by,omitempty"` // Tasks to complete before this one can start
+	SpecifyDatetime *time.Time          `json:"specify_datetime"`                                                                                         // Stored in UTC, the start of the date (UTC) without is_specify_time
+	IsSpecifyTime   bool                `json:"is_specify_time"`
+	// Date and time of specify_datetime in the timezone of the user, no time without is_specify_time
+	SpecifyDate *civilDatetime.CivilDate `gorm:"-" json:"specify_date" swaggertype:"string" format:"date"`
+	SpecifyTime *civilDatetime.CivilTime `gorm:"-" json:"specify_time" swaggertype:"string" example:"15:04:05"`
+	RRule       *string                  `gorm:"column:rrule" json:"rrule"`
+	Occurrence  int                      `json:"occurrence"`
+	Priority    int8                     `json:"priority"`
+	IsComplete  bool                     `json:"is_complete"` // Derived from the status (is_done)
+	IsNotify    int8                     `json:"is_notify"`
+	CreatedAt   *time.Time               `json:"created_at"`
+	UpdatedAt   *time.Time               `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt           `json:"deleted_at" swaggertype:"string" format:"date-time"` // Set when the task is in the trash
+	// Snippets of the fields matching the search (q), by field name
+	Highlights map[string]string `gorm:"-" json:"highlights,omitempty"`
+	// Percentage of the done checklist items and completed subtasks
+	Progress int `gorm:"-" json:"progress"`
+	// Whether one of the blocking tasks is not complete yet
+	IsBlocked bool `gorm:"-" json:"is_blocked"`
+}
+
+// AfterFind computes the progress and the blocked state, the preloads (checklist, children and blocked_by) are loaded before the hook
+func (t *Task) AfterFind(tx *gorm.DB) error {
+	t.Progress = t.progress()
+	t.IsBlocked = false
+	for _, blocker := range t.BlockedBy {
+		if !blocker.IsComplete {
+			t.IsBlocked = true
+			break
+		}
+	}
+	return nil
+}
+
+// Localize converts the datetimes of the task and its subtasks to the timezone of the user.
+// A task without a specified time is due on the same date wherever the user is
+func (t *Task) Localize(loc *time.Location) {
+	t.SpecifyDatetime, t.SpecifyDate, t.SpecifyTime = LocalizeDue(t.SpecifyDatetime, t.IsSpecifyTime, loc)
+	t.CreatedAt = localize(t.CreatedAt, loc)
+	t.UpdatedAt = localize(t.UpdatedAt, loc)
+	if t.DeletedAt.Valid {
+		t.DeletedAt.Time = t.DeletedAt.Time.In(loc)
+	}
+
+	for i := range t.Children {
+		t.Children[i].Localize(loc)
+	}
+	for i := range t.BlockedBy {
+		t.BlockedBy[i].Localize(loc)
+	}
+}
+
+// LocalizeDue converts a stored specify_datetime to the timezone of the user, along with its date and time
+func LocalizeDue(due *time.Time, isSpecifyTime bool, loc *time.Location) (*time.Time, *civilDatetime.CivilDate, *civilDatetime.CivilTime) {
+	if due == nil {
+		return nil, nil, nil
+	}
+
+	if !isSpecifyTime {
+		date := civilDatetime.DateOf(due.UTC())
+		local := date.In(loc)
+		return &local, &date, nil
+	}
+
+	local := due.In(loc)
+	date, clock := civilDatetime.DateOf(local), civilDatetime.TimeOf(local)
+	return &local, &date, &clock
+}
+
+func localize(t *time.Time, loc *time.Location) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	local := t.In(loc)
+	return &local
+}
+
+// progress counts every checklist item and subtask as one step, a task without any is 0 or 100 by its own completion
+func (t *Task) progress() int {
+	steps, done := len(t.Checklist)+len(t.Children), 0
+	if steps == 0 {
+		if t.IsComplete {
+			return 100
+		}
+		return 0
+	}
+
+	for _, item := range t.Checklist {
+		if item.IsDone {
+			done++
+		}
+	}
+	for _, child := range t.Children {
+		if child.IsComplete {
+			done++
+		}
+	}
+
+	return done * 100 / steps
+}
+
+// Highlight fills the snippets of the title, note and url matching the search terms
+func (t *Task) Highlight(terms []string) {
+	t.Highlights = map[string]string{}
+	fields := map[string]string{"title": t.Title, "note": t.Note, "url": t.Url}
+	for name, text := range fields {
+		if snippet := search.Highlight(text, terms); snippet != "" {
+			t.Highlights[name] = snippet
+		}
+	}
+}
+
+// DueTask is a task whose reminder is due, along with the Telegram chat of its owner
+type DueTask struct {
+	ID              int64
+	UserID          int64
+	TelegramID      int64
+	Title           string
+	Note            string
+	Url             string
+	SpecifyDatetime *time.Time
+	IsSpecifyTime   bool
+	Timezone        *string // IANA timezone of the owner
+}
+
+// Location is the timezone of the owner, the default timezone if the owner has none
+func (t DueTask) Location() *time.Location {
+	if t.Timezone != nil {
+		if loc, ok := civilDatetime.LoadLocation(*t.Timezone); ok {
+			return loc
+		}
+	}
+
+	return civilDatetime.DefaultLocation()
+}
+
+// IsDue tells whether the task is due at the time, a task without a time is due from the start of its date for the owner
+func (t DueTask) IsDue(now time.Time) bool {
+	if t.SpecifyDatetime == nil {
+		return false
+	}
+	if t.IsSpecifyTime {
+		return !t.SpecifyDatetime.After(now)
+	}
+
+	return !civilDatetime.DateOf(t.SpecifyDatetime.UTC()).In(t.Location()).After(now)
 }
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TaskAttachment is a file attached to a task, stored under the object key uuid/filename
+type TaskAttachment struct {
+	ID            int64          `json:"id"`
+	TaskID        int64          `json:"task_id"`
+	Filename      string         `json:"filename"`
+	Uuid          string         `json:"-"`
+	ContentType   string         `json:"content_type"`
+	Size          int64          `json:"size"`
+	Thumbnails    string         `json:"-"`                                 // Sizes of the thumbnails (comma separated), stored under uuid/thumbnails/<size>/filename
+	URL           string         `gorm:"-" json:"url"`                      // Temporary download link, signed on every response
+	ThumbnailURLs map[int]string `gorm:"-" json:"thumbnail_urls,omitempty"` // Temporary download links of the thumbnails by size
+	CreatedAt     *time.Time     `json:"created_at"`
+	UpdatedAt     *time.Time     `json:"updated_at"`
+}
+
+// TaskAttachmentUpload is a pending upload of an attachment, the file is sent directly to the storage with the URL
+// and is recorded on the task once the upload is confirmed
+type TaskAttachmentUpload struct {
+	Uuid        string            `json:"uuid"`
+	Filename    string            `json:"filename"`
+	ContentType string            `json:"content_type"`
+	Size        int64             `json:"size"`
+	Method      string            `json:"method"`
+	URL         string            `json:"url"`
+	Headers     map[string]string `json:"headers"`
+	ExpiresIn   int64             `json:"expires_in"`
+}
+
+// ThumbnailSizes returns the sizes of the stored thumbnails
+func (a TaskAttachment) ThumbnailSizes() []int {
+	sizes := []int{}
+	for _, size := range strings.Split(a.Thumbnails, ",") {
+		if px, err := strconv.Atoi(size); err == nil {
+			sizes = append(sizes, px)
+		}
+	}
+
+	return sizes
+}
+
+// ThumbnailFile returns the file of the thumbnail of the size, stored next to the original under the same uuid
+func (a TaskAttachment) ThumbnailFile(size int) string {
+	return "thumbnails/" + strconv.Itoa(size) + "/" + a.Filename
+}
@@ -0,0 +1,14 @@
+package model
+
+import "time"
+
+// TaskChecklistItem is an item of the checklist of a task, ordered by position
+type TaskChecklistItem struct {
+	ID        int64      `json:"id"`
+	TaskID    int64      `json:"task_id"`
+	Title     string     `json:"title"`
+	IsDone    bool       `json:"is_done"`
+	Position  int        `json:"position"`
+	CreatedAt *time.Time `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+}
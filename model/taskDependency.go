@@ -0,0 +1,10 @@
+package model
+
+import "time"
+
+// TaskDependency is a "blocked by" link, the task can not start until the blocking task is complete
+type TaskDependency struct {
+	TaskID      int64      `gorm:"primaryKey" json:"task_id"`
+	BlockedByID int64      `gorm:"primaryKey" json:"blocked_by_id"`
+	CreatedAt   *time.Time `json:"created_at"`
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"go-todolist/utils/civilDatetime"
+	"time"
+)
+
+// TaskOccurrence is a completed occurrence of a recurring task
+type TaskOccurrence struct {
+	ID              int64      `json:"id"`
+	TaskID          int64      `json:"task_id"`
+	Occurrence      int        `json:"occurrence"`
+	SpecifyDatetime *time.Time `json:"specify_datetime"`
+	CompletedAt     *time.Time `json:"completed_at"`
+	CreatedAt       *time.Time `json:"created_at"`
+	// Date and time of specify_datetime in the timezone of the user, no time for a task without is_specify_time
+	SpecifyDate *civilDatetime.CivilDate `gorm:"-" json:"specify_date" swaggertype:"string" format:"date"`
+	SpecifyTime *civilDatetime.CivilTime `gorm:"-" json:"specify_time" swaggertype:"string" example:"15:04:05"`
+}
+
+// Localize converts the datetimes of the occurrence to the timezone of the user, like the ones of its task
+func (o *TaskOccurrence) Localize(loc *time.Location, isSpecifyTime bool) {
+	o.SpecifyDatetime, o.SpecifyDate, o.SpecifyTime = LocalizeDue(o.SpecifyDatetime, isSpecifyTime, loc)
+	o.CompletedAt = localize(o.CompletedAt, loc)
+	o.CreatedAt = localize(o.CreatedAt, loc)
+}
@@ -0,0 +1,8 @@
+package model
+
+// TelegramLink is a one-time code the user sends to the bot with /start to link the Telegram chat
+type TelegramLink struct {
+	Code      string `json:"code"`
+	ExpiresIn int    `json:"expires_in"`
+	StartURL  string `json:"start_url,omitempty"`
+}
@@ -2,6 +2,8 @@ package model
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // Create User struct representing the user table in the database
@@ -17,15 +19,27 @@ import (
 
 // Create User struct representing the user table in the database
 type User struct {
-	ID        uint64    `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	Password  string    `json:"-"`
-	Token     string    `gorm:"-" json:"token,omitempty"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID             uint64    `json:"id"`
+	Username       string    `json:"username"`
+	Email          string    `json:"email"`
+	Password       string    `json:"-"`
+	TelegramID     *int64    `json:"-"`
+	Locale         *string   `json:"locale"`   // Preferred locale of the messages, null negotiates it from Accept-Language
+	Timezone       *string   `json:"timezone"` // IANA timezone of the datetimes, null for DEFAULT_TIMEZONE
+	TelegramLinked bool      `gorm:"-" json:"telegram_linked"`
+	Token          string    `gorm:"-" json:"token,omitempty"`
+	RefreshToken   string    `gorm:"-" json:"refresh_token,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// AfterFind fills the computed fields
+func (u *User) AfterFind(tx *gorm.DB) error {
+	u.TelegramLinked = u.TelegramID != nil
+	return nil
 }
 
 type Token struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
 }
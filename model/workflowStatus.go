@@ -0,0 +1,64 @@
+package model
+
+import "time"
+
+// Default statuses (kanban columns) of a user
+const (
+	WorkflowStatusTodo  = "todo"
+	WorkflowStatusDoing = "doing"
+	WorkflowStatusDone  = "done"
+)
+
+// WorkflowStatus is a column of the user's kanban board, the tasks in a done status are complete
+type WorkflowStatus struct {
+	ID       int64  `json:"id"`
+	UserID   int64  `json:"user_id"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+	IsDone   bool   `json:"is_done"`
+	// Statuses the tasks can move to, none means any status
+	Transitions []WorkflowTransition `gorm:"foreignKey:FromStatusID" json:"transitions,omitempty"`
+	CreatedAt   *time.Time           `json:"created_at"`
+	UpdatedAt   *time.Time           `json:"updated_at"`
+}
+
+// WorkflowTransition allows the tasks to move from a status to another
+type WorkflowTransition struct {
+	FromStatusID int64 `gorm:"primaryKey" json:"from_status_id"`
+	ToStatusID   int64 `gorm:"primaryKey" json:"to_status_id"`
+}
+
+// Allows reports whether a task can move from the status to the other one, staying in the status is always allowed
+func (s WorkflowStatus) Allows(to int64) bool {
+	if to == s.ID || len(s.Transitions) == 0 {
+		return true
+	}
+
+	for _, transition := range s.Transitions {
+		if transition.ToStatusID == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DefaultWorkflowStatuses returns the default statuses of a user
+func DefaultWorkflowStatuses(user_id int64) []WorkflowStatus {
+	return []WorkflowStatus{
+		{UserID: user_id, Name: WorkflowStatusTodo, Position: 1},
+		{UserID: user_id, Name: WorkflowStatusDoing, Position: 2},
+		{UserID: user_id, Name: WorkflowStatusDone, Position: 3, IsDone: true},
+	}
+}
+
+// FirstWorkflowStatus returns the first status (by position) done or not, the new tasks go to the first status not done
+func FirstWorkflowStatus(statuses []WorkflowStatus, done bool) (WorkflowStatus, bool) {
+	for _, status := range statuses {
+		if status.IsDone == done {
+			return status, true
+		}
+	}
+
+	return WorkflowStatus{}, false
+}
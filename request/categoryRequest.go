@@ -3,7 +3,9 @@ package request
 type CategoryGetListRequest struct {
 	Id   int64  `form:"id" json:"id,omitempty"`
 	Name string `form:"name" json:"name,omitempty" binding:"max=100"`
-	Pagination
+	// Sort fields separated by commas, "-" for descending (e.g. -created_at,name)
+	Sort string `form:"sort" json:"sort,omitempty" binding:"max=255"`
+	ListPagination
 }
 
 type CategoryCreateOrUpdateRequest struct {
@@ -10,3 +10,21 @@ type Pagination struct {
 type TableID struct {
 	Id int64 `uri:"id" binding:"required"`
 }
+
+// ListPagination is either the page (offset) or the cursor (keyset) pagination,
+// without page the cursor pagination is used
+type ListPagination struct {
+	// 頁數(請從1開始帶入)
+	Page int64 `form:"page" json:"page,omitempty" binding:"omitempty,gt=0"`
+	// 筆數(請從1開始帶入)
+	Limit int64 `form:"limit" json:"limit" binding:"required,gt=0"`
+	// next_cursor 或 prev_cursor (第一頁留空)
+	Cursor string `form:"cursor" json:"cursor,omitempty" binding:"max=2048"`
+	// 是否計算總筆數(僅 cursor 分頁)
+	WithTotal bool `form:"with_total" json:"with_total,omitempty"`
+}
+
+// IsCursor reports whether the cursor pagination is requested
+func (p ListPagination) IsCursor() bool {
+	return p.Page == 0 || p.Cursor != ""
+}
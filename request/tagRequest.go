@@ -0,0 +1,30 @@
+package request
+
+type TagGetListRequest struct {
+	Name string `form:"name" json:"name,omitempty" binding:"max=50"`
+	Pagination
+}
+
+type TagCreateRequest struct {
+	Name  string `form:"name" json:"name" binding:"required,max=50"`
+	Color string `form:"color" json:"color,omitempty" binding:"omitempty,hexcolor,len=7"`
+}
+
+type TagUpdateRequest struct {
+	Name  string `form:"name" json:"name,omitempty" binding:"max=50"`
+	Color string `form:"color" json:"color,omitempty" binding:"omitempty,hexcolor,len=7"`
+}
+
+type TagGetRequest struct {
+	TableID
+}
+
+type TaskTagAttachRequest struct {
+	// Repeated for several tags, e.g. tag_id=1&tag_id=2
+	TagID []int64 `form:"tag_id" json:"tag_id" binding:"required,max=100,dive,gt=0"`
+}
+
+type TaskTagGetRequest struct {
+	TableID
+	TagID int64 `uri:"tag_id" binding:"required"`
+}
@@ -1,45 +1,123 @@
 package request
 
-import (
-	"mime/multipart"
-	"time"
-)
+import "mime/multipart"
 
 type TaskGetListRequest struct {
-	Id              int64      `form:"id" json:"id,omitempty"`
-	UserID          int64      `form:"user_id" json:"user_id,omitempty"`
-	Title           string     `form:"title" json:"title,omitempty" binding:"max=100"`
-	SpecifyDatetime *time.Time `form:"specify_datetime" json:"specify_datetime,omitempty" time_format:"2006-01-02 15:04:05"`
-	IsSpecifyTime   *bool      `form:"is_specify_time" json:"is_specify_time,omitempty"`
-	IsComplete      *bool      `form:"is_complete" json:"is_complete,omitempty"`
-	Pagination
+	Id              int64   `form:"id" json:"id,omitempty"`
+	Title           string  `form:"title" json:"title,omitempty" binding:"max=100"`
+	Q               string  `form:"q" json:"q,omitempty" binding:"max=255"`
+	ParentID        int64   `form:"parent_id" json:"parent_id,omitempty"`
+	StatusID        int64   `form:"status_id" json:"status_id,omitempty"`
+	SpecifyDatetime *string `form:"specify_datetime" json:"specify_datetime,omitempty" binding:"omitempty,datetime_tz"`
+	IsSpecifyTime   *bool   `form:"is_specify_time" json:"is_specify_time,omitempty"`
+	IsComplete      *bool   `form:"is_complete" json:"is_complete,omitempty"`
+	Blocked         *bool   `form:"blocked" json:"blocked,omitempty"`
+	DueFrom         *string `form:"due_from" json:"due_from,omitempty" binding:"omitempty,datetime_tz"`
+	DueTo           *string `form:"due_to" json:"due_to,omitempty" binding:"omitempty,datetime_tz"`
+	CreatedFrom     *string `form:"created_from" json:"created_from,omitempty" binding:"omitempty,datetime_tz"`
+	CreatedTo       *string `form:"created_to" json:"created_to,omitempty" binding:"omitempty,datetime_tz"`
+	// Repeated for several values, e.g. priority=2&priority=3
+	Priority   []int8  `form:"priority" json:"priority,omitempty" binding:"omitempty,max=3,dive,oneof=1 2 3"`
+	CategoryID []int64 `form:"category_id" json:"category_id,omitempty" binding:"omitempty,max=100,dive,gt=0"`
+	TagID      []int64 `form:"tag_id" json:"tag_id,omitempty" binding:"omitempty,max=100,dive,gt=0"`
+	TagMatch   string  `form:"tag_match" json:"tag_match,omitempty" binding:"omitempty,oneof=any all"`
+	Due        string  `form:"due" json:"due,omitempty" binding:"omitempty,oneof=overdue today upcoming"`
+	// Sort fields separated by commas, "-" for descending (e.g. -priority,specify_datetime)
+	Sort string `form:"sort" json:"sort,omitempty" binding:"max=255"`
+	ListPagination
 }
 
 type TaskCreateRequest struct {
-	UserID          int64                 `form:"user_id" json:"user_id" binding:"required"`
 	CategoryID      int64                 `form:"category_id" json:"category_id" binding:"required"`
+	ParentID        *int64                `form:"parent_id" json:"parent_id,omitempty" binding:"omitempty,gt=0"`
+	StatusID        *int64                `form:"status_id" json:"status_id,omitempty" binding:"omitempty,gt=0"`
 	Title           string                `form:"title" json:"title" binding:"required,max=100"`
 	Note            string                `form:"note" json:"note,omitempty"`
 	Url             string                `form:"url" json:"url,omitempty"`
 	Image           *multipart.FileHeader `form:"image" json:"image,omitempty"`
-	SpecifyDatetime *time.Time            `form:"specify_datetime" json:"specify_datetime,omitempty" time_format:"2006-01-02 15:04:05"`
+	SpecifyDatetime *string               `form:"specify_datetime" json:"specify_datetime,omitempty" binding:"omitempty,datetime_tz"`
+	SpecifyDate     *string               `form:"specify_date" json:"specify_date,omitempty" binding:"omitempty,datetime=2006-01-02"` // Instead of specify_datetime for a task without a time
 	IsSpecifyTime   bool                  `form:"is_specify_time" json:"is_specify_time,omitempty"`
+	RRule           *string               `form:"rrule" json:"rrule,omitempty" binding:"omitempty,max=255"`
 	Priority        int8                  `form:"priority" json:"priority" binding:"required,oneof=1 2 3"`
 	IsComplete      bool                  `form:"is_complete" json:"is_complete,omitempty"`
 }
 
 type TaskUpdateRequest struct {
 	CategoryID      int64                 `form:"category_id" json:"category_id,omitempty"`
+	ParentID        *int64                `form:"parent_id" json:"parent_id,omitempty" binding:"omitempty,gte=0"` // 0 makes it a top-level task
 	Title           string                `form:"title" json:"title,omitempty" binding:"max=100"`
 	Note            string                `form:"note" json:"note,omitempty"`
 	Url             string                `form:"url" json:"url,omitempty"`
 	Image           *multipart.FileHeader `form:"image" json:"image,omitempty"`
-	SpecifyDatetime *time.Time            `form:"specify_datetime" json:"specify_datetime,omitempty" time_format:"2006-01-02 15:04:05"`
+	SpecifyDatetime *string               `form:"specify_datetime" json:"specify_datetime,omitempty" binding:"omitempty,datetime_tz"`
+	SpecifyDate     *string               `form:"specify_date" json:"specify_date,omitempty" binding:"omitempty,datetime=2006-01-02"` // Instead of specify_datetime for a task without a time
 	IsSpecifyTime   bool                  `form:"is_specify_time" json:"is_specify_time,omitempty"`
+	RRule           *string               `form:"rrule" json:"rrule,omitempty" binding:"omitempty,max=255"`
 	Priority        int8                  `form:"priority" json:"priority" binding:"required,oneof=1 2 3"`
 	IsComplete      bool                  `form:"is_complete" json:"is_complete,omitempty"`
+	// Complete the subtasks as well when the task is completed
+	CompleteChildren bool `form:"complete_children" json:"complete_children,omitempty"`
 }
 
 type TaskGetRequest struct {
 	TableID
 }
+
+type TaskMoveRequest struct {
+	StatusID int64 `form:"status_id" json:"status_id" binding:"required,gt=0"`
+	// Position in the status column (from 1), the end of the column by default
+	Position int `form:"position" json:"position,omitempty" binding:"omitempty,gt=0"`
+}
+
+type TaskOccurrenceListRequest struct {
+	Pagination
+}
+
+type TaskAttachmentCreateRequest struct {
+	Files []*multipart.FileHeader `form:"files" binding:"required"`
+}
+
+type TaskAttachmentGetRequest struct {
+	TableID
+	AttachmentID int64 `uri:"attachment_id" binding:"required"`
+}
+
+type TaskAttachmentUploadRequest struct {
+	Filename    string `form:"filename" json:"filename" binding:"required"`
+	ContentType string `form:"content_type" json:"content_type" binding:"required"`
+	Size        int64  `form:"size" json:"size" binding:"required,gt=0"`
+}
+
+type TaskAttachmentConfirmRequest struct {
+	TableID
+	Uuid string `uri:"uuid" binding:"required,uuid4"`
+}
+
+type TaskChecklistItemCreateRequest struct {
+	Title string `form:"title" json:"title" binding:"required,max=255"`
+}
+
+type TaskChecklistItemGetRequest struct {
+	TableID
+	ItemID int64 `uri:"item_id" binding:"required"`
+}
+
+type TaskChecklistItemUpdateRequest struct {
+	Title  *string `form:"title" json:"title,omitempty" binding:"omitempty,min=1,max=255"`
+	IsDone *bool   `form:"is_done" json:"is_done,omitempty"`
+}
+
+type TaskChecklistReorderRequest struct {
+	// Every item of the checklist, in the new order
+	ItemIDs []int64 `form:"item_ids" json:"item_ids" binding:"required,dive,gt=0"`
+}
+
+type TaskDependencyCreateRequest struct {
+	BlockedByID int64 `form:"blocked_by_id" json:"blocked_by_id" binding:"required,gt=0"`
+}
+
+type TaskDependencyGetRequest struct {
+	TableID
+	BlockedByID int64 `uri:"blocked_by_id" binding:"required"`
+}
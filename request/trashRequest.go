@@ -0,0 +1,9 @@
+package request
+
+type TrashListRequest struct {
+	Pagination
+}
+
+type TrashGetRequest struct {
+	TableID
+}
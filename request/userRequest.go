@@ -4,6 +4,8 @@ package request
 type LoginRequest struct {
 	Email    string `form:"email" json:"email" binding:"required,email,max=50"`
 	Password string `form:"password" json:"password" binding:"required,min=6"`
+	// 裝置名稱
+	Device string `form:"device" json:"device,omitempty" binding:"max=50"`
 }
 
 // Create register request struct when user register from /register URL
@@ -12,3 +14,21 @@ type RegisterRequest struct {
 	Email    string `form:"email" json:"email" binding:"required,email,max=50"`
 	Password string `form:"password" json:"password" binding:"required,min=6"`
 }
+
+// Create refresh token request struct when user refresh the token from /auth/refresh URL
+type RefreshTokenRequest struct {
+	RefreshToken string `form:"refresh_token" json:"refresh_token" binding:"required"`
+}
+
+// Create profile update request struct when user update the profile from /auth/profile URL
+type ProfileUpdateRequest struct {
+	// Preferred locale of the messages (en, zh-TW), empty to negotiate it from Accept-Language
+	Locale *string `form:"locale" json:"locale,omitempty" binding:"omitempty,max=10"`
+	// IANA timezone of the datetimes (e.g. Asia/Taipei), empty for the default one
+	Timezone *string `form:"timezone" json:"timezone,omitempty" binding:"omitempty,max=64"`
+}
+
+// Create session request struct when user revoke a session from /auth/sessions/:id URL
+type SessionRequest struct {
+	Id string `uri:"id" binding:"required,uuid4"`
+}
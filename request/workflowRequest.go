@@ -0,0 +1,25 @@
+package request
+
+type WorkflowStatusCreateRequest struct {
+	Name   string `form:"name" json:"name" binding:"required,max=50"`
+	IsDone bool   `form:"is_done" json:"is_done,omitempty"`
+}
+
+type WorkflowStatusUpdateRequest struct {
+	Name   *string `form:"name" json:"name,omitempty" binding:"omitempty,min=1,max=50"`
+	IsDone *bool   `form:"is_done" json:"is_done,omitempty"`
+}
+
+type WorkflowStatusGetRequest struct {
+	TableID
+}
+
+type WorkflowStatusReorderRequest struct {
+	// Every status of the user, in the new order
+	StatusIDs []int64 `form:"status_ids" json:"status_ids" binding:"required,dive,gt=0"`
+}
+
+type WorkflowTransitionsRequest struct {
+	// Statuses the tasks can move to, empty allows any status
+	ToStatusIDs []int64 `form:"to_status_ids" json:"to_status_ids" binding:"max=50,dive,gt=0"`
+}
@@ -7,17 +7,18 @@ import (
 	"go-todolist/entity"
 	"go-todolist/middleware"
 	"go-todolist/services"
-	s3_utils "go-todolist/utils/aws"
 	gorm_utils "go-todolist/utils/gorm"
-
+	"go-todolist/utils/paginator"
 	// "go-todolist/utils/log"
 	redis_utils "go-todolist/utils/redis"
+	storage_utils "go-todolist/utils/storage"
+	telegram_utils "go-todolist/utils/telegram"
+	"go-todolist/utils/validation"
 	"os"
 
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
-	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
 	"github.com/joho/godotenv"
@@ -27,23 +28,44 @@ import (
 var (
 	v1 = "/api/v1"
 
-	db                    *gorm.DB                         = gorm_utils.InitMySQL()
-	rdb                   *redis.Client                    = redis_utils.InitRedis()
-	awsS3                 *s3.Client                       = s3_utils.InitS3()
-	userEntity            entity.UserEntity                = entity.NewUserEntity(db)
-	categoryEntity        entity.CategoryEntity            = entity.NewCategoryEntity(db)
-	taskEntity            entity.TaskEntity                = entity.NewTaskEntity(db)
-	redisEntity           entity.RedisEntity               = entity.NewRedisEntity(rdb)
-	s3Entity              entity.S3Entity                  = entity.NewS3Entity(awsS3)
-	userService           services.UserService             = services.NewUserService(userEntity)
-	categoryService       services.CategoryService         = services.NewCategoryService(categoryEntity)
-	taskService           services.TaskService             = services.NewTaskService(taskEntity, s3Entity)
-	jwtService            services.JWTService              = services.NewJWTService(redisEntity, userEntity)
-	userController                                         = controller.NewUserController(userService, jwtService)
-	categoryController                                     = controller.NewCategoryController(categoryService, categoryEntity)
-	taskController                                         = controller.NewTaskController(taskService, taskEntity)
-	googleOauthController                                  = controller.NewGoogleOauthController(jwtService)
-	rateLimiterMiddleware middleware.RateLimiterMiddleware = middleware.NewRateLimiterMiddleware(redisEntity)
+	db                       *gorm.DB                         = gorm_utils.InitMySQL()
+	rdb                      *redis.Client                    = redis_utils.InitRedis()
+	storageConfig            storage_utils.Config             = storage_utils.InitStorage()
+	telegramBot              *telegram_utils.Client           = telegram_utils.InitTelegram()
+	userEntity               entity.UserEntity                = entity.NewUserEntity(db)
+	categoryEntity           entity.CategoryEntity            = entity.NewCategoryEntity(db)
+	taskEntity               entity.TaskEntity                = entity.NewTaskEntity(db)
+	tagEntity                entity.TagEntity                 = entity.NewTagEntity(db)
+	workflowEntity           entity.WorkflowEntity            = entity.NewWorkflowEntity(db)
+	taskAttachmentEntity     entity.TaskAttachmentEntity      = entity.NewTaskAttachmentEntity(db)
+	taskChecklistEntity      entity.TaskChecklistEntity       = entity.NewTaskChecklistEntity(db)
+	taskDependencyEntity     entity.TaskDependencyEntity      = entity.NewTaskDependencyEntity(db)
+	redisEntity              entity.RedisEntity               = entity.NewRedisEntity(rdb)
+	s3Entity                 entity.S3Entity                  = entity.NewStorageEntity(storageConfig)
+	telegramEntity           entity.TelegramEntity            = entity.NewTelegramEntity(telegramBot)
+	userService              services.UserService             = services.NewUserService(userEntity)
+	categoryService          services.CategoryService         = services.NewCategoryService(categoryEntity)
+	tagService               services.TagService              = services.NewTagService(tagEntity)
+	taskAttachmentService    services.TaskAttachmentService   = services.NewTaskAttachmentService(taskAttachmentEntity, s3Entity, redisEntity, storageConfig)
+	taskService              services.TaskService             = services.NewTaskService(taskEntity, workflowEntity, taskAttachmentService)
+	jwtService               services.JWTService              = services.NewJWTService(redisEntity, userEntity)
+	notifyService            services.NotifyService           = services.NewNotifyService(taskEntity, telegramEntity)
+	telegramService          services.TelegramService         = services.NewTelegramService(redisEntity, userEntity, telegramEntity)
+	storageGCService         services.StorageGCService        = services.NewStorageGCService(taskAttachmentEntity, s3Entity, redisEntity)
+	trashService             services.TrashService            = services.NewTrashService(taskEntity, categoryEntity)
+	userController                                            = controller.NewUserController(userService, jwtService)
+	categoryController                                        = controller.NewCategoryController(categoryService, categoryEntity)
+	tagController                                             = controller.NewTagController(tagService, tagEntity, taskEntity, taskAttachmentService)
+	taskController                                            = controller.NewTaskController(taskService, taskEntity, categoryEntity, workflowEntity, taskAttachmentService, storageConfig.Limits)
+	workflowController                                        = controller.NewWorkflowController(workflowEntity)
+	taskAttachmentController                                  = controller.NewTaskAttachmentController(taskAttachmentService, taskAttachmentEntity, taskEntity, storageConfig.Limits)
+	taskChecklistController                                   = controller.NewTaskChecklistController(taskChecklistEntity, taskEntity)
+	taskDependencyController                                  = controller.NewTaskDependencyController(taskDependencyEntity, taskEntity, taskAttachmentService)
+	googleOauthController                                     = controller.NewGoogleOauthController(jwtService)
+	telegramController                                        = controller.NewTelegramController(telegramService, userEntity)
+	storageController                                         = controller.NewStorageController(storageConfig)
+	trashController                                           = controller.NewTrashController(trashService, taskEntity, categoryEntity, taskAttachmentService)
+	rateLimiterMiddleware    middleware.RateLimiterMiddleware = middleware.NewRateLimiterMiddleware(redisEntity)
 )
 
 func SetupRouter() *gin.Engine {
@@ -55,14 +77,50 @@ func SetupRouter() *gin.Engine {
 
 	appPort := fmt.Sprintf(":%s", os.Getenv("SERVER_PORT"))
 
+	// The list cursors are signed, the server does not start without their key
+	paginator.InitCursor()
+
 	// Closing the database when the program stop
 	defer gorm_utils.Close(db)
 	defer redis_utils.Close(rdb)
 
+	// The unique index only enforces TASK_TITLE_UNIQUE once the title scope of the tasks is applied by `go run . title-scope`
+	synced, errScope := taskEntity.TitleScopeSynced(services.GetTaskTitleUnique())
+	if errScope != nil {
+		panic("Failed to check the title scope of the tasks : " + errScope.Error())
+	}
+	if !synced {
+		panic("The title scope of the tasks does not match TASK_TITLE_UNIQUE, run `go run . title-scope` first")
+	}
+
+	// Send the Telegram reminders of due tasks in the background
+	stopNotifier := make(chan struct{})
+	defer close(stopNotifier)
+	go notifyService.Start(services.GetNotifyInterval(), stopNotifier)
+
+	// Remove the orphaned objects of the storage in the background
+	stopStorageGC := make(chan struct{})
+	defer close(stopStorageGC)
+	go storageGCService.Start(services.GetStorageGCInterval(), services.GetStorageGCGracePeriod(), stopStorageGC)
+
+	// Purge the trash older than the retention period in the background
+	stopTrashPurge := make(chan struct{})
+	defer close(stopTrashPurge)
+	go trashService.Start(services.GetTrashPurgeInterval(), services.GetTrashRetention(), stopTrashPurge)
+
 	// r := gin.New()
 	r := gin.Default()
 	r.Use(middleware.CORS())
 
+	// Name the fields of the validation errors after the request fields
+	validation.Init()
+
+	// Negotiate the locale of the messages, the errors are written in it
+	r.Use(middleware.Locale())
+
+	// Write the responses of the errors added by the controllers
+	r.Use(middleware.ErrorHandler())
+
 	// IPv6 0:0:0:0:0:0:0:1 = ::1 (Omit 0) = 0.0.0.0/0
 	r.SetTrustedProxies([]string{"::1", "192.168.0.0/16", "172.16.0.0/12", "127.0.0.1/8", "10.0.0.0/8", "0.0.0.0/0"})
 
@@ -73,6 +131,7 @@ func SetupRouter() *gin.Engine {
 	{
 		authRoutes.POST("/login", userController.Login)
 		authRoutes.POST("/register", userController.Register)
+		authRoutes.POST("/refresh", userController.RefreshToken)
 	}
 
 	oauthRoutes := r.Group(v1 + "/oauth")
@@ -81,7 +140,18 @@ func SetupRouter() *gin.Engine {
 		oauthRoutes.GET("/google/callback", googleOauthController.GoogleCallBack)
 	}
 
-	test := r.Group(v1+"/test", middleware.AuthorizeJWT(jwtService))
+	telegramRoutes := r.Group(v1 + "/telegram")
+	{
+		telegramRoutes.POST("/webhook", telegramController.Webhook)
+	}
+
+	storageRoutes := r.Group(v1 + "/storage")
+	{
+		storageRoutes.GET("/*key", storageController.Download)
+		storageRoutes.PUT("/*key", storageController.Upload)
+	}
+
+	test := r.Group(v1+"/test", middleware.AuthorizeJWT(jwtService), middleware.UserPreferences(userEntity))
 	{
 		test.GET("/token", func(c *gin.Context) {
 			c.JSON(200, gin.H{
@@ -90,13 +160,17 @@ func SetupRouter() *gin.Engine {
 		})
 	}
 
-	auth := r.Group(v1+"/auth", middleware.AuthorizeJWT(jwtService))
+	auth := r.Group(v1+"/auth", middleware.AuthorizeJWT(jwtService), middleware.UserPreferences(userEntity))
 	{
-		auth.POST("/refresh", userController.RefreshToken)
 		auth.POST("/logout", userController.Logout)
+		auth.GET("/sessions", userController.Sessions)
+		auth.DELETE("/sessions", userController.RevokeOtherSessions)
+		auth.DELETE("/sessions/:id", userController.RevokeSession)
+		auth.GET("/profile", userController.Profile)
+		auth.PATCH("/profile", userController.UpdateProfile)
 	}
 
-	categories := r.Group(v1+"/category", middleware.AuthorizeJWT(jwtService))
+	categories := r.Group(v1+"/category", middleware.AuthorizeJWT(jwtService), middleware.UserPreferences(userEntity))
 	{
 		categories.POST("/", categoryController.Create)
 		categories.GET("/", categoryController.GetByList)
@@ -105,13 +179,68 @@ func SetupRouter() *gin.Engine {
 		categories.DELETE("/:id", categoryController.Delete)
 	}
 
-	tasks := r.Group(v1+"/task", middleware.AuthorizeJWT(jwtService))
+	tags := r.Group(v1+"/tag", middleware.AuthorizeJWT(jwtService), middleware.UserPreferences(userEntity))
+	{
+		tags.POST("/", tagController.Create)
+		tags.GET("/", tagController.GetByList)
+		tags.GET("/:id", tagController.Get)
+		tags.PATCH("/:id", tagController.Update)
+		tags.DELETE("/:id", tagController.Delete)
+	}
+
+	workflow := r.Group(v1+"/workflow", middleware.AuthorizeJWT(jwtService), middleware.UserPreferences(userEntity))
+	{
+		workflow.GET("/statuses", workflowController.GetByList)
+		workflow.POST("/statuses", workflowController.Create)
+		workflow.PUT("/statuses/order", workflowController.Reorder)
+		workflow.PATCH("/statuses/:id", workflowController.Update)
+		workflow.DELETE("/statuses/:id", workflowController.Delete)
+		workflow.PUT("/statuses/:id/transitions", workflowController.SetTransitions)
+	}
+
+	tasks := r.Group(v1+"/task", middleware.AuthorizeJWT(jwtService), middleware.UserPreferences(userEntity))
 	{
 		tasks.POST("/", taskController.Create)
 		tasks.GET("/", taskController.GetByList)
 		tasks.GET("/:id", taskController.Get)
 		tasks.PATCH("/:id", taskController.Update)
 		tasks.DELETE("/:id", taskController.Delete)
+		tasks.GET("/:id/occurrences", taskController.Occurrences)
+		tasks.POST("/:id/move", taskController.Move)
+		tasks.POST("/:id/attachments", taskAttachmentController.Create)
+		tasks.GET("/:id/attachments", taskAttachmentController.GetByList)
+		tasks.GET("/:id/attachments/:attachment_id", taskAttachmentController.Get)
+		tasks.DELETE("/:id/attachments/:attachment_id", taskAttachmentController.Delete)
+		tasks.POST("/:id/attachments/uploads", taskAttachmentController.CreateUpload)
+		tasks.POST("/:id/attachments/uploads/:uuid", taskAttachmentController.ConfirmUpload)
+		tasks.POST("/:id/checklist", taskChecklistController.Create)
+		tasks.GET("/:id/checklist", taskChecklistController.GetByList)
+		tasks.PUT("/:id/checklist/order", taskChecklistController.Reorder)
+		tasks.PATCH("/:id/checklist/:item_id", taskChecklistController.Update)
+		tasks.DELETE("/:id/checklist/:item_id", taskChecklistController.Delete)
+		tasks.POST("/:id/checklist/:item_id/toggle", taskChecklistController.Toggle)
+		tasks.POST("/:id/blocked-by", taskDependencyController.Create)
+		tasks.DELETE("/:id/blocked-by/:blocked_by_id", taskDependencyController.Delete)
+		tasks.POST("/:id/tags", tagController.Attach)
+		tasks.DELETE("/:id/tags/:tag_id", tagController.Detach)
+	}
+
+	trash := r.Group(v1+"/trash", middleware.AuthorizeJWT(jwtService), middleware.UserPreferences(userEntity))
+	{
+		trash.GET("/tasks", trashController.GetTasks)
+		trash.GET("/categories", trashController.GetCategories)
+		trash.POST("/tasks/:id/restore", trashController.RestoreTask)
+		trash.DELETE("/tasks/:id", trashController.PurgeTask)
+		trash.POST("/categories/:id/restore", trashController.RestoreCategory)
+		trash.DELETE("/categories/:id", trashController.PurgeCategory)
+		trash.DELETE("/", trashController.Empty)
+	}
+
+	telegram := r.Group(v1+"/telegram", middleware.AuthorizeJWT(jwtService), middleware.UserPreferences(userEntity))
+	{
+		telegram.POST("/link", telegramController.CreateLink)
+		telegram.GET("/link", telegramController.GetLink)
+		telegram.DELETE("/link", telegramController.Unlink)
 	}
 
 	swagger := r.Group(v1 + "/swagger")
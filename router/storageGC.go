@@ -0,0 +1,35 @@
+package router
+
+import (
+	"flag"
+	"fmt"
+	"go-todolist/services"
+	gorm_utils "go-todolist/utils/gorm"
+	redis_utils "go-todolist/utils/redis"
+	"os"
+	"time"
+)
+
+// RunStorageGC is the gc subcommand, it sweeps the orphaned objects of the storage once and prints the report
+//
+//	go run . gc [-dry-run] [-grace 24h]
+func RunStorageGC(args []string) {
+	defer gorm_utils.Close(db)
+	defer redis_utils.Close(rdb)
+
+	flags := flag.NewFlagSet("gc", flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "only report the orphaned objects")
+	grace := flags.Duration("grace", services.GetStorageGCGracePeriod(), "minimum age of the removed objects")
+	flags.Parse(args)
+
+	report, err := storageGCService.Sweep(*grace, *dryRun)
+	for _, orphan := range report.Orphans {
+		fmt.Printf("%s\t%d\t%s\n", orphan.Key, orphan.Size, orphan.LastModified.Format(time.RFC3339))
+	}
+	fmt.Printf("dry-run: %t, scanned: %d, orphans: %d (%d bytes), removed: %d, failed: %d\n", report.DryRun, report.Scanned, len(report.Orphans), report.OrphanSize, report.Removed, report.Failed)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "gc failed : "+err.Error())
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,26 @@
+package router
+
+import (
+	"fmt"
+	"go-todolist/services"
+	gorm_utils "go-todolist/utils/gorm"
+	redis_utils "go-todolist/utils/redis"
+	"os"
+)
+
+// RunTitleScope is the title-scope subcommand, it applies TASK_TITLE_UNIQUE to the title scope of all the tasks once,
+// it fails if titles are already repeated in the scope, they have to be renamed first
+//
+//	go run . title-scope
+func RunTitleScope() {
+	defer gorm_utils.Close(db)
+	defer redis_utils.Close(rdb)
+
+	unique := services.GetTaskTitleUnique()
+	if err := taskEntity.SyncTitleScope(unique); err != nil {
+		fmt.Fprintln(os.Stderr, "title-scope failed : "+err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Printf("title scope: %s\n", unique)
+}
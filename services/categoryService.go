@@ -10,8 +10,8 @@ import (
 )
 
 type CategoryService interface {
-	CreateCategory(category request.CategoryCreateOrUpdateRequest) (c model.Category, e error)
-	UpdateCategory(category request.CategoryCreateOrUpdateRequest, id int64) (c model.Category, e error)
+	CreateCategory(category request.CategoryCreateOrUpdateRequest, user_id int64) (c model.Category, e error)
+	UpdateCategory(category request.CategoryCreateOrUpdateRequest, id int64, user_id int64) (c model.Category, e error)
 }
 
 type categoryService struct {
@@ -22,7 +22,7 @@ func NewCategoryService(categoryEntity entity.CategoryEntity) CategoryService {
 	return &categoryService{categoryEntity: categoryEntity}
 }
 
-func (s *categoryService) CreateCategory(category request.CategoryCreateOrUpdateRequest) (c model.Category, e error) {
+func (s *categoryService) CreateCategory(category request.CategoryCreateOrUpdateRequest, user_id int64) (c model.Category, e error) {
 	categoryToCreate := model.Category{}
 	err := smapping.FillStruct(&categoryToCreate, smapping.MapFields(&category))
 	if err != nil {
@@ -30,6 +30,7 @@ func (s *categoryService) CreateCategory(category request.CategoryCreateOrUpdate
 		return categoryToCreate, err
 	}
 
+	categoryToCreate.UserID = &user_id
 	res, resErr := s.categoryEntity.CreateCategory(categoryToCreate)
 	if resErr != nil {
 		return res, resErr
@@ -38,7 +39,7 @@ func (s *categoryService) CreateCategory(category request.CategoryCreateOrUpdate
 	return res, nil
 }
 
-func (s *categoryService) UpdateCategory(category request.CategoryCreateOrUpdateRequest, id int64) (c model.Category, e error) {
+func (s *categoryService) UpdateCategory(category request.CategoryCreateOrUpdateRequest, id int64, user_id int64) (c model.Category, e error) {
 	categoryToUpdate := model.Category{}
 	err := smapping.FillStruct(&categoryToUpdate, smapping.MapFields(&category))
 	if err != nil {
@@ -47,6 +48,7 @@ func (s *categoryService) UpdateCategory(category request.CategoryCreateOrUpdate
 	}
 
 	categoryToUpdate.ID = id
+	categoryToUpdate.UserID = &user_id
 	res, resErr := s.categoryEntity.UpdateCategory(categoryToUpdate)
 	if resErr != nil {
 		return res, resErr
@@ -1,36 +1,74 @@
 package services
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"go-todolist/entity"
+	"go-todolist/model"
 	"reflect"
 
+	"go-todolist/utils/apperr"
 	"go-todolist/utils/log"
+	"go-todolist/utils/responses"
 	"os"
 	"strconv"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/golang-jwt/jwt/v4"
 )
 
 // JWT Service is a contract of what a JWT Service should be able to do.
 type JWTService interface {
-	// Generate a new token
-	GenerateToken(userID uint64, t time.Time) string
+	// Generate a new token and register it as a session
+	GenerateToken(userID uint64, t time.Time, session model.Session) string
 
 	// Validate the token
 	ValidateToken(token string) (*jwt.Token, error)
 
-	// Refresh the token
-	RefreshToken(token string) string
+	// Generate a new access token together with a refresh token for a new session
+	GenerateTokenPair(userID uint64, session model.Session) model.Token
 
-	// User logout and remove the token from redis
+	// Rotate the refresh token and return a new token pair
+	RefreshToken(refreshToken string) (model.Token, error)
+
+	// User logout and remove the current session from redis
 	Logout(authHeader string) bool
 
 	// Authorize JWT for middleware
 	AuthJWT(authHeader string) string
 
-	GoogleGenerateToken(data interface{}) string
+	GoogleGenerateToken(data interface{}, session model.Session) model.Token
+
+	// List the active sessions of the user
+	ListSessions(userID uint64, currentID string) []model.Session
+
+	// Revoke a single session of the user
+	RevokeSession(userID uint64, sessionID string) bool
+
+	// Revoke every session of the user except the current one
+	RevokeOtherSessions(userID uint64, currentID string) int
+}
+
+var (
+	// ErrRefreshTokenInvalid the refresh token does not exist, expired or its session was revoked
+	ErrRefreshTokenInvalid = apperr.New(apperr.Unauthorized, responses.RefreshTokenInvalid)
+
+	// ErrRefreshTokenReused an already rotated refresh token was presented again
+	ErrRefreshTokenReused = apperr.New(apperr.Unauthorized, responses.RefreshTokenReused)
+
+	// ErrSignatureFailed the token could not be signed
+	ErrSignatureFailed = apperr.New(apperr.Internal, responses.SignatureFailed)
+)
+
+// refreshTokenRecord is the redis value of a refresh token
+type refreshTokenRecord struct {
+	UserID    uint64 `json:"user_id"`
+	SessionID string `json:"session_id"`
 }
 
 // jwtCustomClaim is a struct that contains the custom claims for the JWT
@@ -103,9 +141,161 @@ func GetTokenTTL() int {
 	return intTTL
 }
 
+// GetRefreshTokenTTL Get refresh token TTL from .env file
+func GetRefreshTokenTTL() int {
+	stringTTL := os.Getenv("JWT_REFRESH_TTL")
+	if stringTTL == "" {
+		// If the environment variable is empty, use a default value (14 days)
+		stringTTL = "1209600"
+	}
+
+	intTTL, _ := strconv.Atoi(stringTTL)
+
+	return intTTL
+}
+
+// refreshTokenKey Get the redis key of a refresh token, only the hash of the token is stored
+func refreshTokenKey(hash string) string {
+	return "refresh_token:" + hash
+}
+
+// refreshTokenUsedKey Get the redis key marking a refresh token as already rotated
+func refreshTokenUsedKey(hash string) string {
+	return "refresh_token_used:" + hash
+}
+
+// refreshFamilyKey Get the redis key of the refresh token family, one family per session
+func refreshFamilyKey(sessionID string) string {
+	return "refresh_family:" + sessionID
+}
+
+// hashRefreshToken Hash the refresh token with sha256
+func hashRefreshToken(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+	return hex.EncodeToString(sum[:])
+}
+
+// sessionKey Get the redis key of a single session
+func sessionKey(userID uint64, sessionID string) string {
+	return "session:" + strconv.FormatUint(userID, 10) + ":" + sessionID
+}
+
+// sessionLastSeenKey Get the redis key of the last activity of a session, it is kept apart from the session
+// so that recording the activity never writes back a session read before a refresh
+func sessionLastSeenKey(userID uint64, sessionID string) string {
+	return sessionKey(userID, sessionID) + ":last_seen"
+}
+
+// sessionsKey Get the redis key of the set holding every session ID of the user
+func sessionsKey(userID uint64) string {
+	return "sessions:" + strconv.FormatUint(userID, 10)
+}
+
+// getSession Get the session from redis
+func (s *jwtService) getSession(userID uint64, sessionID string) (model.Session, error) {
+	session := model.Session{}
+	get, err := s.redisEntity.Get(sessionKey(userID, sessionID))
+	if err != nil {
+		return session, err
+	}
+
+	err = json.Unmarshal([]byte(fmt.Sprintf("%v", get)), &session)
+	return session, err
+}
+
+// setSession Save the session to redis
+func (s *jwtService) setSession(session model.Session, expire time.Duration) error {
+	session.Current = false
+	value, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.redisEntity.Set(sessionKey(session.UserID, session.ID), string(value), expire)
+	return err
+}
+
+// touchSession Record the activity of the session, the key lives as long as a refresh of the session
+func (s *jwtService) touchSession(userID uint64, sessionID string, t time.Time) error {
+	refreshTTL := time.Duration(GetRefreshTokenTTL()) * time.Second
+	_, err := s.redisEntity.Set(sessionLastSeenKey(userID, sessionID), t.Format(time.RFC3339Nano), refreshTTL)
+	return err
+}
+
+// lastSeen Get the last activity of the session, the session itself holds the time of its last refresh
+func (s *jwtService) lastSeen(session model.Session) time.Time {
+	get, err := s.redisEntity.Get(sessionLastSeenKey(session.UserID, session.ID))
+	if err != nil {
+		return session.LastSeen
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, fmt.Sprintf("%v", get))
+	if err != nil || t.Before(session.LastSeen) {
+		return session.LastSeen
+	}
+
+	return t
+}
+
+// deleteSession Remove the session and its refresh token family from redis
+func (s *jwtService) deleteSession(userID uint64, sessionID string) bool {
+	get, err := s.redisEntity.Del(sessionKey(userID, sessionID))
+	if err != nil {
+		log.Error("Failed to delete the session in redis : " + err.Error())
+		return false
+	}
+	s.redisEntity.Del(sessionLastSeenKey(userID, sessionID))
+	s.redisEntity.SRem(sessionsKey(userID), sessionID)
+	s.redisEntity.Del(refreshFamilyKey(sessionID))
+
+	return get == int64(1)
+}
+
+// generateRefreshToken Create an opaque refresh token and make it the current one of the session family
+func (s *jwtService) generateRefreshToken(userID uint64, sessionID string) string {
+	refreshTTL := time.Duration(GetRefreshTokenTTL()) * time.Second
+
+	random := make([]byte, 32)
+	_, randErr := rand.Read(random)
+	if randErr != nil {
+		log.Error("Failed to generate the refresh token : " + randErr.Error())
+		return ""
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(random)
+	hash := hashRefreshToken(refreshToken)
+
+	record, _ := json.Marshal(refreshTokenRecord{UserID: userID, SessionID: sessionID})
+	_, setErr := s.redisEntity.Set(refreshTokenKey(hash), string(record), refreshTTL)
+	if setErr != nil {
+		log.Error("Failed to set the refresh token in redis : " + setErr.Error())
+		return ""
+	}
+
+	_, setErr = s.redisEntity.Set(refreshFamilyKey(sessionID), hash, refreshTTL)
+	if setErr != nil {
+		log.Error("Failed to set the refresh token family in redis : " + setErr.Error())
+		return ""
+	}
+
+	return refreshToken
+}
+
 // GenerateToken Create a new token object, specifying signing method and the claims
-func (s *jwtService) GenerateToken(userID uint64, t time.Time) string {
-	jwtTTL := GetTokenTTL()
+func (s *jwtService) GenerateToken(userID uint64, t time.Time, session model.Session) string {
+	refreshTTL := GetRefreshTokenTTL()
+	now := time.Now()
+
+	// A new session gets its own ID (jti), a refreshed one keeps it
+	if session.ID == "" {
+		uuidV4, uuidV4Err := uuid.NewV4()
+		if uuidV4Err != nil {
+			log.Error("Failed to generate the session ID : " + uuidV4Err.Error())
+			return ""
+		}
+		session.ID = uuidV4.String()
+		session.CreatedAt = now
+	}
+
 	// Create the Claims struct with the required claims for the JWT
 	claims := &jwtCustomClaim{
 		// userId is the only required field
@@ -114,9 +304,11 @@ func (s *jwtService) GenerateToken(userID uint64, t time.Time) string {
 			// 1 day expiration
 			ExpiresAt: jwt.NewNumericDate(t),
 			// when the token was issued/created (now)
-			IssuedAt: jwt.NewNumericDate(time.Now()),
+			IssuedAt: jwt.NewNumericDate(now),
 			// Who creates the token
 			Issuer: s.issuer,
+			// Session ID
+			ID: session.ID,
 		},
 	}
 
@@ -130,10 +322,16 @@ func (s *jwtService) GenerateToken(userID uint64, t time.Time) string {
 		return ""
 	}
 
-	_, setRedisErr := s.redisEntity.Set("token"+strconv.FormatUint(userID, 10), token, time.Duration(jwtTTL)*time.Second)
+	// The session lives as long as its refresh token, the access token expiry is checked by the JWT itself
+	session.UserID = userID
+	session.Token = token
+	session.LastSeen = now
+	setRedisErr := s.setSession(session, time.Duration(refreshTTL)*time.Second)
 	if setRedisErr != nil {
 		log.Error("Failed to set the token in redis : " + setRedisErr.Error())
 	}
+	s.redisEntity.SAdd(sessionsKey(userID), session.ID)
+	s.redisEntity.Expire(sessionsKey(userID), time.Duration(refreshTTL)*time.Second)
 
 	// Return the token to the user, along with an expiration time
 	return token
@@ -154,23 +352,94 @@ func (s *jwtService) ValidateToken(token string) (*jwt.Token, error) {
 	})
 }
 
-// RefreshToken refresh the token
-func (s *jwtService) RefreshToken(authHeader string) string {
+// GenerateTokenPair Create a new session with an access token and a refresh token
+func (s *jwtService) GenerateTokenPair(userID uint64, session model.Session) model.Token {
 	jwtTTL := GetTokenTTL()
+	pair := model.Token{}
+
+	// A new session always starts a new refresh token family
+	session.ID = ""
+	pair.Token = s.GenerateToken(userID, time.Now().Add(time.Duration(jwtTTL)*time.Second), session)
+	if len(pair.Token) < 1 {
+		return pair
+	}
+
 	claims := &jwtCustomClaim{}
-	_, err := getUserDataByToken(authHeader, claims, s.secretKey)
+	_, err := getUserDataByToken(pair.Token, claims, s.secretKey)
 	if err != nil {
-		log.Error("Failed to get user data (RefreshToken) : " + err.Error())
-		return ""
+		log.Error("Failed to get user data (GenerateTokenPair) : " + err.Error())
+		return model.Token{}
+	}
+
+	pair.RefreshToken = s.generateRefreshToken(userID, claims.ID)
+	if len(pair.RefreshToken) < 1 {
+		s.deleteSession(userID, claims.ID)
+		return model.Token{}
+	}
+
+	return pair
+}
+
+// RefreshToken rotate the refresh token, a reused refresh token revokes the whole family (session)
+func (s *jwtService) RefreshToken(refreshToken string) (model.Token, error) {
+	jwtTTL := GetTokenTTL()
+	refreshTTL := time.Duration(GetRefreshTokenTTL()) * time.Second
+	pair := model.Token{}
+	hash := hashRefreshToken(refreshToken)
+
+	get, err := s.redisEntity.Get(refreshTokenKey(hash))
+	if err != nil {
+		return pair, ErrRefreshTokenInvalid
+	}
+
+	record := refreshTokenRecord{}
+	err = json.Unmarshal([]byte(fmt.Sprintf("%v", get)), &record)
+	if err != nil {
+		log.Error("Failed to get the refresh token (RefreshToken) : " + err.Error())
+		return pair, ErrRefreshTokenInvalid
+	}
+
+	// The family has been revoked or expired
+	current, err := s.redisEntity.Get(refreshFamilyKey(record.SessionID))
+	if err != nil {
+		return pair, ErrRefreshTokenInvalid
+	}
+
+	// Mark the token as used atomically, only the first caller may rotate it
+	first, err := s.redisEntity.SetNX(refreshTokenUsedKey(hash), 1, refreshTTL)
+	if err != nil {
+		log.Error("Failed to set the refresh token used in redis : " + err.Error())
+		return pair, ErrRefreshTokenInvalid
+	}
+	if !first || fmt.Sprintf("%v", current) != hash {
+		log.Warn("Refresh token reuse detected, revoke session : " + record.SessionID)
+		s.deleteSession(record.UserID, record.SessionID)
+		return pair, ErrRefreshTokenReused
+	}
+
+	// Keep the same session, only the tokens are replaced
+	session, sessionErr := s.getSession(record.UserID, record.SessionID)
+	if sessionErr != nil {
+		log.Error("Failed to get the session in redis (RefreshToken) : " + sessionErr.Error())
+		s.redisEntity.Del(refreshFamilyKey(record.SessionID))
+		return pair, ErrRefreshTokenInvalid
 	}
 
 	// int to time.Duration
-	token := s.GenerateToken(claims.UserID, time.Now().Add(time.Duration(jwtTTL)*time.Second))
+	pair.Token = s.GenerateToken(record.UserID, time.Now().Add(time.Duration(jwtTTL)*time.Second), session)
+	if len(pair.Token) < 1 {
+		return model.Token{}, ErrRefreshTokenInvalid
+	}
 
-	return token
+	pair.RefreshToken = s.generateRefreshToken(record.UserID, record.SessionID)
+	if len(pair.RefreshToken) < 1 {
+		return model.Token{}, ErrRefreshTokenInvalid
+	}
+
+	return pair, nil
 }
 
-// Logout User logout and remove token from redis
+// Logout User logout and remove the current session from redis
 func (s *jwtService) Logout(authHeader string) bool {
 	claims := &jwtCustomClaim{}
 	_, erro := getUserDataByToken(authHeader, claims, s.secretKey)
@@ -179,16 +448,7 @@ func (s *jwtService) Logout(authHeader string) bool {
 		return false
 	}
 
-	get, err := s.redisEntity.Del("token" + strconv.Itoa(int(claims.UserID)))
-	if get == int64(1) {
-		return true
-	}
-	if err != nil {
-		log.Error("Failed to delete the token in redis : " + err.Error())
-		return false
-	}
-
-	return false
+	return s.deleteSession(claims.UserID, claims.ID)
 }
 
 // AuthJWT Get the redis token and return the middleware
@@ -200,23 +460,74 @@ func (s *jwtService) AuthJWT(authHeader string) string {
 		return ""
 	}
 
-	get, err := s.redisEntity.Get("token" + strconv.Itoa(int(claims.UserID)))
+	session, err := s.getSession(claims.UserID, claims.ID)
 	if err != nil {
-		log.Error("Failed to set the token in redis (AuthJWT) : " + err.Error())
+		log.Error("Failed to get the session in redis (AuthJWT) : " + err.Error())
 		return ""
 	}
 
-	return fmt.Sprintf("%v", get)
+	// Record the activity of the session, the session itself is left untouched
+	if setErr := s.touchSession(claims.UserID, claims.ID, time.Now()); setErr != nil {
+		log.Error("Failed to set the last activity of the session in redis (AuthJWT) : " + setErr.Error())
+	}
+
+	return session.Token
 }
 
-func (s *jwtService) GoogleGenerateToken(data interface{}) string {
-	jwtTTL := GetTokenTTL()
+func (s *jwtService) GoogleGenerateToken(data interface{}, session model.Session) model.Token {
 	googleInfo := reflect.ValueOf(data).Elem()
 	findByEmail := s.userEntity.FindByEmail(googleInfo.FieldByName("Email").String())
 	if findByEmail.ID == 0 {
-		return ""
+		return model.Token{}
 	}
 
-	token := s.GenerateToken(googleInfo.FieldByName("Id").Uint(), time.Now().Add(time.Duration(jwtTTL)*time.Second))
-	return token
+	return s.GenerateTokenPair(findByEmail.ID, session)
+}
+
+// ListSessions List the active sessions of the user, expired ones are cleaned up
+func (s *jwtService) ListSessions(userID uint64, currentID string) []model.Session {
+	sessions := []model.Session{}
+	sessionIDs, err := s.redisEntity.SMembers(sessionsKey(userID))
+	if err != nil {
+		log.Error("Failed to get the sessions in redis : " + err.Error())
+		return sessions
+	}
+
+	for _, sessionID := range sessionIDs {
+		session, sessionErr := s.getSession(userID, sessionID)
+		if sessionErr != nil {
+			s.redisEntity.SRem(sessionsKey(userID), sessionID)
+			continue
+		}
+
+		session.Token = ""
+		session.LastSeen = s.lastSeen(session)
+		session.Current = session.ID == currentID
+		sessions = append(sessions, session)
+	}
+
+	return sessions
+}
+
+// RevokeSession Revoke a single session of the user
+func (s *jwtService) RevokeSession(userID uint64, sessionID string) bool {
+	return s.deleteSession(userID, sessionID)
+}
+
+// RevokeOtherSessions Revoke every session of the user except the current one and return the count
+func (s *jwtService) RevokeOtherSessions(userID uint64, currentID string) int {
+	revoked := 0
+	sessionIDs, err := s.redisEntity.SMembers(sessionsKey(userID))
+	if err != nil {
+		log.Error("Failed to get the sessions in redis : " + err.Error())
+		return revoked
+	}
+
+	for _, sessionID := range sessionIDs {
+		if sessionID != currentID && s.deleteSession(userID, sessionID) {
+			revoked++
+		}
+	}
+
+	return revoked
 }
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"go-todolist/entity"
+	"go-todolist/model"
+	"go-todolist/utils/log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NotifyService sends the reminders of due tasks
+type NotifyService interface {
+	// Start scans for due tasks on every interval until stop is closed, run it in a goroutine
+	Start(interval time.Duration, stop <-chan struct{})
+
+	// NotifyDueTasks sends the Telegram reminders of the due tasks and returns how many were sent
+	NotifyDueTasks(now time.Time) int
+}
+
+type notifyService struct {
+	taskEntity     entity.TaskEntity
+	telegramEntity entity.TelegramEntity
+}
+
+// Maximum number of reminders sent per scan
+const notifyBatchSize = 100
+
+func NewNotifyService(taskEntity entity.TaskEntity, telegramEntity entity.TelegramEntity) NotifyService {
+	return &notifyService{
+		taskEntity:     taskEntity,
+		telegramEntity: telegramEntity,
+	}
+}
+
+// GetNotifyInterval Get the notifier scan interval from .env file
+func GetNotifyInterval() time.Duration {
+	stringInterval := os.Getenv("NOTIFY_INTERVAL")
+	if stringInterval == "" {
+		// If the environment variable is empty, use a default value
+		stringInterval = "60"
+	}
+
+	intInterval, _ := strconv.Atoi(stringInterval)
+	if intInterval < 1 {
+		intInterval = 60
+	}
+
+	return time.Duration(intInterval) * time.Second
+}
+
+func (s *notifyService) Start(interval time.Duration, stop <-chan struct{}) {
+	if !s.telegramEntity.Enabled() {
+		log.Warn("Notifier is not started : telegram is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			s.NotifyDueTasks(now)
+		}
+	}
+}
+
+func (s *notifyService) NotifyDueTasks(now time.Time) int {
+	tasks, err := s.taskEntity.GetDueTasks(now, notifyBatchSize)
+	if err != nil {
+		log.Error("Failed to get due tasks : " + err.Error())
+		return 0
+	}
+
+	sent := 0
+	for _, task := range tasks {
+		if !task.IsDue(now) {
+			continue
+		}
+
+		// Claim the reminder first, another replica may have taken it already
+		claimed, claimErr := s.taskEntity.UpdateTaskNotify(task.ID, model.NotifyNone, model.NotifyTelegram)
+		if claimErr != nil {
+			log.Error("Failed to claim the task reminder : " + claimErr.Error())
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		sendErr := s.telegramEntity.SendMessage(task.TelegramID, reminderText(task))
+		if sendErr != nil {
+			log.Error("Failed to send the task reminder (task " + strconv.FormatInt(task.ID, 10) + ") : " + sendErr.Error())
+
+			// Release the claim, so that it is retried on the next scan
+			_, releaseErr := s.taskEntity.UpdateTaskNotify(task.ID, model.NotifyTelegram, model.NotifyNone)
+			if releaseErr != nil {
+				log.Error("Failed to release the task reminder : " + releaseErr.Error())
+			}
+			continue
+		}
+
+		sent++
+	}
+
+	return sent
+}
+
+// reminderText builds the Telegram message of the task
+func reminderText(task model.DueTask) string {
+	lines := []string{"⏰ " + task.Title}
+
+	// In the timezone of the owner
+	if due, _, _ := model.LocalizeDue(task.SpecifyDatetime, task.IsSpecifyTime, task.Location()); due != nil {
+		layout := "2006-01-02"
+		if task.IsSpecifyTime {
+			layout = "2006-01-02 15:04 MST"
+		}
+		lines = append(lines, due.Format(layout))
+	}
+	if task.Note != "" {
+		lines = append(lines, task.Note)
+	}
+	if task.Url != "" {
+		lines = append(lines, task.Url)
+	}
+
+	return strings.Join(lines, "\n")
+}
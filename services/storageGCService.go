@@ -0,0 +1,178 @@
+package services
+
+import (
+	"go-todolist/entity"
+	"go-todolist/utils/log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// StorageGCService removes the stored objects no attachment refers to
+// (deleted tasks and users, failed or unconfirmed uploads)
+type StorageGCService interface {
+	// Start sweeps the storage on every interval until stop is closed, run it in a goroutine
+	Start(interval time.Duration, grace time.Duration, stop <-chan struct{})
+
+	// Sweep lists the objects of the storage and removes the unreferenced ones older than the grace period,
+	// with dryRun the objects are only reported
+	Sweep(grace time.Duration, dryRun bool) (StorageGCReport, error)
+}
+
+// StorageGCReport is the result of a sweep
+type StorageGCReport struct {
+	DryRun bool
+	// Number of listed objects
+	Scanned int
+	// Unreferenced objects older than the grace period
+	Orphans []entity.FileObject
+	// Total size of the orphans (bytes)
+	OrphanSize int64
+	// Number of removed orphans
+	Removed int
+	// Orphans that could not be removed
+	Failed int
+}
+
+type storageGCService struct {
+	taskAttachmentEntity entity.TaskAttachmentEntity
+	s3Entity             entity.S3Entity
+	redisEntity          entity.RedisEntity
+}
+
+// Only one replica sweeps at a time
+const storageGCLockKey = "storage_gc_lock"
+
+func NewStorageGCService(taskAttachmentEntity entity.TaskAttachmentEntity, s3Entity entity.S3Entity, redisEntity entity.RedisEntity) StorageGCService {
+	return &storageGCService{
+		taskAttachmentEntity: taskAttachmentEntity,
+		s3Entity:             s3Entity,
+		redisEntity:          redisEntity,
+	}
+}
+
+// GetStorageGCInterval Get the sweep interval from .env file, 0 disables the scheduled sweep
+func GetStorageGCInterval() time.Duration {
+	stringInterval := os.Getenv("STORAGE_GC_INTERVAL")
+	if stringInterval == "" {
+		// If the environment variable is empty, use a default value
+		stringInterval = "86400"
+	}
+
+	intInterval, _ := strconv.Atoi(stringInterval)
+
+	return time.Duration(intInterval) * time.Second
+}
+
+// GetStorageGCGracePeriod Get the minimum age of the removed objects from .env file
+func GetStorageGCGracePeriod() time.Duration {
+	stringGrace := os.Getenv("STORAGE_GC_GRACE_PERIOD")
+	if stringGrace == "" {
+		// If the environment variable is empty, use a default value
+		stringGrace = "86400"
+	}
+
+	intGrace, _ := strconv.Atoi(stringGrace)
+	if intGrace < 0 {
+		intGrace = 86400
+	}
+
+	return time.Duration(intGrace) * time.Second
+}
+
+func (s *storageGCService) Start(interval time.Duration, grace time.Duration, stop <-chan struct{}) {
+	if interval <= 0 {
+		log.Warn("Storage garbage collector is not started : STORAGE_GC_INTERVAL is 0")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			locked, err := s.redisEntity.SetNX(storageGCLockKey, os.Getpid(), interval)
+			if err != nil || !locked {
+				continue
+			}
+
+			report, err := s.Sweep(grace, false)
+			if err != nil {
+				log.Error("Storage garbage collector failed : " + err.Error())
+			}
+			log.Infof("Storage garbage collector : scanned %d, orphans %d (%d bytes), removed %d, failed %d", report.Scanned, len(report.Orphans), report.OrphanSize, report.Removed, report.Failed)
+		}
+	}
+}
+
+func (s *storageGCService) Sweep(grace time.Duration, dryRun bool) (StorageGCReport, error) {
+	report := StorageGCReport{DryRun: dryRun, Orphans: []entity.FileObject{}}
+	before := time.Now().Add(-grace)
+
+	err := s.s3Entity.FileList(func(objects []entity.FileObject) error {
+		report.Scanned += len(objects)
+
+		orphans, err := s.orphans(objects, before)
+		if err != nil {
+			return err
+		}
+
+		for _, orphan := range orphans {
+			report.Orphans = append(report.Orphans, orphan)
+			report.OrphanSize += orphan.Size
+			if dryRun {
+				continue
+			}
+
+			file, uuidV4 := entity.SplitObjectKey(orphan.Key)
+			if removeErr := s.s3Entity.FileRemove(file, uuidV4); removeErr != nil {
+				log.Error("Storage garbage collector failed to remove " + orphan.Key + " : " + removeErr.Error())
+				report.Failed++
+				continue
+			}
+			report.Removed++
+		}
+
+		return nil
+	})
+
+	return report, err
+}
+
+// orphans returns the objects older than before that are neither an attachment nor one of its thumbnails
+func (s *storageGCService) orphans(objects []entity.FileObject, before time.Time) ([]entity.FileObject, error) {
+	uuids := []string{}
+	seen := map[string]bool{}
+	for _, object := range objects {
+		_, uuidV4 := entity.SplitObjectKey(object.Key)
+		if !seen[uuidV4] {
+			seen[uuidV4] = true
+			uuids = append(uuids, uuidV4)
+		}
+	}
+
+	attachments, err := s.taskAttachmentEntity.GetTaskAttachmentsByUuids(uuids)
+	if err != nil {
+		return nil, err
+	}
+
+	referenced := map[string]bool{}
+	for _, attachment := range attachments {
+		referenced[attachment.Uuid+"/"+attachment.Filename] = true
+		for _, size := range attachment.ThumbnailSizes() {
+			referenced[attachment.Uuid+"/"+attachment.ThumbnailFile(size)] = true
+		}
+	}
+
+	orphans := []entity.FileObject{}
+	for _, object := range objects {
+		if !referenced[object.Key] && object.LastModified.Before(before) {
+			orphans = append(orphans, object)
+		}
+	}
+
+	return orphans, nil
+}
@@ -0,0 +1,56 @@
+package services
+
+import (
+	"go-todolist/entity"
+	"go-todolist/model"
+	"go-todolist/request"
+	"go-todolist/utils/log"
+	"strings"
+
+	"github.com/mashingan/smapping"
+)
+
+type TagService interface {
+	CreateTag(tag request.TagCreateRequest, user_id int64) (c model.Tag, e error)
+	UpdateTag(tag request.TagUpdateRequest, id int64, user_id int64) (c model.Tag, e error)
+}
+
+type tagService struct {
+	tagEntity entity.TagEntity
+}
+
+func NewTagService(tagEntity entity.TagEntity) TagService {
+	return &tagService{tagEntity: tagEntity}
+}
+
+func (s *tagService) CreateTag(tag request.TagCreateRequest, user_id int64) (c model.Tag, e error) {
+	tagToCreate := model.Tag{}
+	err := smapping.FillStruct(&tagToCreate, smapping.MapFields(&tag))
+	if err != nil {
+		log.Error("CreateTag Failed map : " + err.Error())
+		return tagToCreate, err
+	}
+
+	tagToCreate.UserID = user_id
+	tagToCreate.Color = strings.ToLower(tagToCreate.Color)
+	if tagToCreate.Color == "" {
+		tagToCreate.Color = model.TagColorDefault
+	}
+
+	return s.tagEntity.CreateTag(tagToCreate)
+}
+
+func (s *tagService) UpdateTag(tag request.TagUpdateRequest, id int64, user_id int64) (c model.Tag, e error) {
+	tagToUpdate := model.Tag{}
+	err := smapping.FillStruct(&tagToUpdate, smapping.MapFields(&tag))
+	if err != nil {
+		log.Error("UpdateTag Failed map : " + err.Error())
+		return tagToUpdate, err
+	}
+
+	tagToUpdate.ID = id
+	tagToUpdate.UserID = user_id
+	tagToUpdate.Color = strings.ToLower(tagToUpdate.Color)
+
+	return s.tagEntity.UpdateTag(tagToUpdate)
+}
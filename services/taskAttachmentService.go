@@ -0,0 +1,420 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"go-todolist/entity"
+	"go-todolist/model"
+	"go-todolist/request"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/imaging"
+	"go-todolist/utils/log"
+	"go-todolist/utils/responses"
+	"go-todolist/utils/storage"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+type TaskAttachmentService interface {
+	CreateTaskAttachments(task_id int64, files []*multipart.FileHeader) (attachments []model.TaskAttachment, err error)
+	DeleteTaskAttachment(attachment model.TaskAttachment) error
+	GetTaskAttachmentURL(attachment model.TaskAttachment) (string, error)
+
+	// CreateUploadURL issues a pre-signed URL to upload an attachment directly to the storage
+	CreateUploadURL(task_id int64, upload request.TaskAttachmentUploadRequest) (model.TaskAttachmentUpload, error)
+	// ConfirmUpload records the uploaded file of a pre-signed URL on the task
+	ConfirmUpload(task_id int64, uuidV4 string) (model.TaskAttachment, error)
+
+	// SignTaskAttachments fills the temporary download links of the attachments
+	SignTaskAttachments(attachments []model.TaskAttachment)
+	// CountTaskAttachments counts the attachments of the task along with its pending uploads, which MaxFiles limits
+	CountTaskAttachments(task_id int64) (int64, error)
+}
+
+var (
+	ErrAttachmentUploadNotFound = apperr.New(apperr.NotFound, responses.RecordNotFound)
+	ErrAttachmentFileMissing    = apperr.New(apperr.Validation, responses.AttachmentNotUploaded)
+	ErrAttachmentImageInvalid   = apperr.New(apperr.Validation, responses.AttachmentImageInvalid)
+	ErrTooManyAttachments       = apperr.New(apperr.Validation, responses.TooManyAttachments)
+)
+
+type taskAttachmentService struct {
+	taskAttachmentEntity entity.TaskAttachmentEntity
+	s3Entity             entity.S3Entity
+	redisEntity          entity.RedisEntity
+	config               storage.Config
+}
+
+func NewTaskAttachmentService(taskAttachmentEntity entity.TaskAttachmentEntity, s3Entity entity.S3Entity, redisEntity entity.RedisEntity, config storage.Config) TaskAttachmentService {
+	return &taskAttachmentService{
+		taskAttachmentEntity: taskAttachmentEntity,
+		s3Entity:             s3Entity,
+		redisEntity:          redisEntity,
+		config:               config,
+	}
+}
+
+// attachmentUploadKey Get the redis key of a pending upload
+func attachmentUploadKey(uuidV4 string) string {
+	return "attachment_upload:" + uuidV4
+}
+
+// attachmentUploadsKey Get the redis key of the sorted set of the pending uploads of the task, scored by their expiry
+func attachmentUploadsKey(task_id int64) string {
+	return "attachment_uploads:" + strconv.FormatInt(task_id, 10)
+}
+
+// pendingUploads counts the uploads of the task not confirmed yet and not expired, except the upload of the uuid
+func (s *taskAttachmentService) pendingUploads(task_id int64, except string) (int64, error) {
+	now := time.Now().Unix()
+	count, err := s.redisEntity.ZCount(attachmentUploadsKey(task_id), strconv.FormatInt(now, 10), "+inf")
+	if err != nil {
+		return 0, err
+	}
+
+	if except != "" {
+		if expireAt, scoreErr := s.redisEntity.ZScore(attachmentUploadsKey(task_id), except); scoreErr == nil && int64(expireAt) >= now {
+			count--
+		}
+	}
+
+	return count, nil
+}
+
+func (s *taskAttachmentService) CountTaskAttachments(task_id int64) (int64, error) {
+	count, err := s.taskAttachmentEntity.CountTaskAttachment(task_id)
+	if err != nil {
+		return count, err
+	}
+
+	pending, err := s.pendingUploads(task_id, "")
+	return count + pending, err
+}
+
+// withAttachmentSlot runs fc with the row of the task locked, ErrTooManyAttachments if the task holds MaxFiles attachments already.
+// The pending uploads are counted, except the upload of the uuid which is being confirmed
+func (s *taskAttachmentService) withAttachmentSlot(task_id int64, except string, fc func(tx entity.TaskAttachmentEntity) error) error {
+	return s.taskAttachmentEntity.Transaction(func(tx entity.TaskAttachmentEntity) error {
+		if err := tx.LockTaskAttachments(task_id); err != nil {
+			return err
+		}
+
+		count, err := tx.CountTaskAttachment(task_id)
+		if err != nil {
+			return err
+		}
+		pending, err := s.pendingUploads(task_id, except)
+		if err != nil {
+			return err
+		}
+		if count+pending >= int64(s.config.Limits.MaxFiles) {
+			return ErrTooManyAttachments
+		}
+
+		return fc(tx)
+	})
+}
+
+// CreateTaskAttachments uploads every file under its own uuid and records it on the task,
+// the files already uploaded are kept if a later one fails
+func (s *taskAttachmentService) CreateTaskAttachments(task_id int64, files []*multipart.FileHeader) (attachments []model.TaskAttachment, err error) {
+	for _, file := range files {
+		uuidV4, uuidV4Err := uuid.NewV4()
+		if uuidV4Err != nil {
+			return attachments, uuidV4Err
+		}
+
+		data, readErr := readFile(file)
+		if readErr != nil {
+			return attachments, readErr
+		}
+
+		attachment := model.TaskAttachment{TaskID: task_id, Filename: file.Filename, Uuid: uuidV4.String()}
+		storeErr := s.storeFile(&attachment, data)
+		if storeErr != nil {
+			return attachments, storeErr
+		}
+
+		attachment, createErr := s.createTaskAttachment(attachment, "")
+		if createErr != nil {
+			return attachments, createErr
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	s.SignTaskAttachments(attachments)
+
+	return attachments, nil
+}
+
+func readFile(file *multipart.FileHeader) ([]byte, error) {
+	f, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(f)
+}
+
+// storeFile validates the content of the file by its magic bytes and stores it,
+// the metadata of the images is stripped and their thumbnails are stored next to them
+func (s *taskAttachmentService) storeFile(attachment *model.TaskAttachment, data []byte) error {
+	attachment.ContentType = storage.DetectContentType(data)
+	checkErr := s.config.Limits.CheckFile(attachment.Filename, int64(len(data)), attachment.ContentType)
+	if checkErr != nil {
+		return checkErr
+	}
+
+	thumbnails := map[int][]byte{}
+	if imaging.Supported(attachment.ContentType) {
+		processed, processErr := imaging.Process(data, attachment.ContentType, s.config.ThumbnailSizes)
+		if processErr != nil {
+			log.Error("storeFile Failed process : " + processErr.Error())
+			return ErrAttachmentImageInvalid
+		}
+		data = processed.Original
+		thumbnails = processed.Thumbnails
+	}
+
+	sizes := []string{}
+	for size, thumbnail := range thumbnails {
+		_, uploadErr := s.s3Entity.FileUpload(attachment.ThumbnailFile(size), attachment.Uuid, attachment.ContentType, bytes.NewReader(thumbnail))
+		if uploadErr != nil {
+			s.removeFiles(*attachment)
+			return apperr.Wrap(apperr.Upstream, uploadErr)
+		}
+		sizes = append(sizes, strconv.Itoa(size))
+		attachment.Thumbnails = strings.Join(sizes, ",")
+	}
+
+	_, uploadErr := s.s3Entity.FileUpload(attachment.Filename, attachment.Uuid, attachment.ContentType, bytes.NewReader(data))
+	if uploadErr != nil {
+		s.removeFiles(*attachment)
+		return apperr.Wrap(apperr.Upstream, uploadErr)
+	}
+	attachment.Size = int64(len(data))
+
+	return nil
+}
+
+// removeFiles removes the thumbnails and then the stored file of the attachment
+func (s *taskAttachmentService) removeFiles(attachment model.TaskAttachment) error {
+	for _, size := range attachment.ThumbnailSizes() {
+		removeErr := s.s3Entity.FileRemove(attachment.ThumbnailFile(size), attachment.Uuid)
+		if removeErr != nil {
+			return removeErr
+		}
+	}
+
+	return s.s3Entity.FileRemove(attachment.Filename, attachment.Uuid)
+}
+
+// createTaskAttachment records the stored file within the limit of the attachments of the task (the pending upload of the uuid aside),
+// the file is removed if it cannot be recorded
+func (s *taskAttachmentService) createTaskAttachment(attachment model.TaskAttachment, except string) (model.TaskAttachment, error) {
+	res := attachment
+	createErr := s.withAttachmentSlot(attachment.TaskID, except, func(tx entity.TaskAttachmentEntity) error {
+		var err error
+		res, err = tx.CreateTaskAttachment(attachment)
+		return err
+	})
+	if createErr != nil {
+		// Do not leave an object nobody refers to
+		if removeErr := s.removeFiles(attachment); removeErr != nil {
+			log.Error("createTaskAttachment Failed remove : " + removeErr.Error())
+		}
+		return res, createErr
+	}
+
+	return res, nil
+}
+
+func (s *taskAttachmentService) DeleteTaskAttachment(attachment model.TaskAttachment) error {
+	removeErr := s.removeFiles(attachment)
+	if removeErr != nil {
+		return removeErr
+	}
+
+	return s.taskAttachmentEntity.DeleteTaskAttachment(attachment.ID, attachment.TaskID)
+}
+
+// GetTaskAttachmentURL returns a temporary link to download the attachment
+func (s *taskAttachmentService) GetTaskAttachmentURL(attachment model.TaskAttachment) (string, error) {
+	return s.s3Entity.FileURL(attachment.Filename, attachment.Uuid, s.config.URLTTL)
+}
+
+func (s *taskAttachmentService) SignTaskAttachments(attachments []model.TaskAttachment) {
+	for i := range attachments {
+		url, err := s.GetTaskAttachmentURL(attachments[i])
+		if err != nil {
+			log.Error("SignTaskAttachments Failed sign : " + err.Error())
+			continue
+		}
+		attachments[i].URL = url
+
+		for _, size := range attachments[i].ThumbnailSizes() {
+			thumbnailURL, err := s.s3Entity.FileURL(attachments[i].ThumbnailFile(size), attachments[i].Uuid, s.config.URLTTL)
+			if err != nil {
+				log.Error("SignTaskAttachments Failed sign : " + err.Error())
+				continue
+			}
+			if attachments[i].ThumbnailURLs == nil {
+				attachments[i].ThumbnailURLs = map[int]string{}
+			}
+			attachments[i].ThumbnailURLs[size] = thumbnailURL
+		}
+	}
+}
+
+func (s *taskAttachmentService) CreateUploadURL(task_id int64, upload request.TaskAttachmentUploadRequest) (model.TaskAttachmentUpload, error) {
+	res := model.TaskAttachmentUpload{
+		Filename: upload.Filename,
+		Size:     upload.Size,
+		Method:   http.MethodPut,
+	}
+
+	contentType, _, err := mime.ParseMediaType(upload.ContentType)
+	if err != nil {
+		return res, storage.ErrFileTypeNotAllowed
+	}
+	res.ContentType = contentType
+
+	checkErr := s.config.Limits.CheckFile(upload.Filename, upload.Size, contentType)
+	if checkErr != nil {
+		return res, checkErr
+	}
+
+	uuidV4, err := uuid.NewV4()
+	if err != nil {
+		return res, err
+	}
+	res.Uuid = uuidV4.String()
+
+	res.URL, err = s.s3Entity.FileUploadURL(upload.Filename, res.Uuid, contentType, s.config.UploadURLTTL)
+	if err != nil {
+		return res, err
+	}
+	res.Headers = map[string]string{"Content-Type": contentType}
+	res.ExpiresIn = int64(s.config.UploadURLTTL.Seconds())
+
+	pending, err := json.Marshal(model.TaskAttachment{TaskID: task_id, Filename: upload.Filename, ContentType: contentType, Size: upload.Size})
+	if err != nil {
+		return res, err
+	}
+
+	// Leave time to confirm an upload started just before the URL expired, the upload takes a place among the attachments of the task until then
+	expire := 2 * s.config.UploadURLTTL
+	err = s.withAttachmentSlot(task_id, "", func(tx entity.TaskAttachmentEntity) error {
+		key := attachmentUploadsKey(task_id)
+		s.redisEntity.ZRemRangeByScore(key, "-inf", "("+strconv.FormatInt(time.Now().Unix(), 10))
+		if _, err := s.redisEntity.ZAdd(key, float64(time.Now().Add(expire).Unix()), res.Uuid); err != nil {
+			return err
+		}
+		s.redisEntity.Expire(key, expire)
+		return nil
+	})
+	if err != nil {
+		return res, err
+	}
+
+	_, err = s.redisEntity.Set(attachmentUploadKey(res.Uuid), pending, expire)
+	if err != nil {
+		s.redisEntity.ZRem(attachmentUploadsKey(task_id), res.Uuid)
+		return res, err
+	}
+
+	return res, nil
+}
+
+func (s *taskAttachmentService) ConfirmUpload(task_id int64, uuidV4 string) (model.TaskAttachment, error) {
+	attachment := model.TaskAttachment{}
+
+	value, err := s.redisEntity.Get(attachmentUploadKey(uuidV4))
+	pending, ok := value.(string)
+	if err != nil || !ok || pending == "" {
+		return attachment, ErrAttachmentUploadNotFound
+	}
+
+	err = json.Unmarshal([]byte(pending), &attachment)
+	if err != nil || attachment.TaskID != task_id {
+		return model.TaskAttachment{}, ErrAttachmentUploadNotFound
+	}
+	attachment.Uuid = uuidV4
+
+	stat, statErr := s.s3Entity.FileStat(attachment.Filename, attachment.Uuid)
+	if statErr == entity.ErrFileNotFound {
+		return attachment, ErrAttachmentFileMissing
+	}
+	if statErr != nil {
+		return attachment, statErr
+	}
+
+	// The upload may differ from what was announced
+	checkErr := s.config.Limits.CheckFile(attachment.Filename, stat.Size, attachment.ContentType)
+	if checkErr == nil {
+		checkErr = s.storeUploadedFile(&attachment)
+	}
+
+	// The pre-signed URL can still write the staging object until it expires, it is never the attachment
+	if removeErr := s.s3Entity.FileRemove(attachment.Filename, uuidV4); removeErr != nil {
+		log.Error("ConfirmUpload Failed remove : " + removeErr.Error())
+	}
+	if checkErr != nil {
+		s.redisEntity.Del(attachmentUploadKey(uuidV4))
+		s.redisEntity.ZRem(attachmentUploadsKey(task_id), uuidV4)
+		return attachment, checkErr
+	}
+
+	// A confirmed upload cannot be recorded twice
+	pending, err = s.redisEntity.GetDel(attachmentUploadKey(uuidV4))
+	if err != nil || pending == "" {
+		if removeErr := s.removeFiles(attachment); removeErr != nil {
+			log.Error("ConfirmUpload Failed remove : " + removeErr.Error())
+		}
+		return attachment, ErrAttachmentUploadNotFound
+	}
+
+	// The place taken by the upload is given to the attachment
+	res, createErr := s.createTaskAttachment(attachment, uuidV4)
+	s.redisEntity.ZRem(attachmentUploadsKey(task_id), uuidV4)
+	if createErr != nil {
+		return res, createErr
+	}
+
+	attachments := []model.TaskAttachment{res}
+	s.SignTaskAttachments(attachments)
+
+	return attachments[0], nil
+}
+
+// storeUploadedFile processes the file uploaded with a pre-signed URL the same way as the files uploaded through the API,
+// and stores it under a new uuid, so that the pre-signed URL can not overwrite the checked file
+func (s *taskAttachmentService) storeUploadedFile(attachment *model.TaskAttachment) error {
+	f, readErr := s.s3Entity.FileRead(attachment.Filename, attachment.Uuid)
+	if readErr != nil {
+		return readErr
+	}
+	defer f.Close()
+
+	data, readErr := io.ReadAll(io.LimitReader(f, s.config.Limits.MaxFileSize+1))
+	if readErr != nil {
+		return readErr
+	}
+
+	uuidV4, uuidV4Err := uuid.NewV4()
+	if uuidV4Err != nil {
+		return uuidV4Err
+	}
+	attachment.Uuid = uuidV4.String()
+
+	return s.storeFile(attachment, data)
+}
@@ -1,111 +1,414 @@
 package services
 
 import (
-	"fmt"
 	"go-todolist/entity"
 	"go-todolist/model"
 	"go-todolist/request"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/civilDatetime"
 	"go-todolist/utils/log"
+	"go-todolist/utils/responses"
+	"go-todolist/utils/rrule"
+	"mime/multipart"
+	"os"
+	"sort"
+	"time"
 
-	"github.com/gofrs/uuid"
 	"github.com/mashingan/smapping"
 )
 
 type TaskService interface {
-	CreateTask(task request.TaskCreateRequest, img_uuid interface{}) (c model.Task, e error)
-	UpdateTask(task request.TaskUpdateRequest, id int64, user_id int64, img string, img_uuid interface{}) (c model.Task, e error)
+	CreateTask(task request.TaskCreateRequest, user_id int64, loc *time.Location) (c model.Task, e error)
+	UpdateTask(task request.TaskUpdateRequest, current model.Task, loc *time.Location) (c model.Task, e error)
+	MoveTask(current model.Task, status model.WorkflowStatus, position int, loc *time.Location) (c model.Task, e error)
 }
 
 type taskService struct {
-	taskEntity entity.TaskEntity
-	s3Entity   entity.S3Entity
+	taskEntity            entity.TaskEntity
+	workflowEntity        entity.WorkflowEntity
+	taskAttachmentService TaskAttachmentService
 }
 
-func NewTaskService(taskEntity entity.TaskEntity, s3Entity entity.S3Entity) TaskService {
+func NewTaskService(taskEntity entity.TaskEntity, workflowEntity entity.WorkflowEntity, taskAttachmentService TaskAttachmentService) TaskService {
 	return &taskService{
-		taskEntity: taskEntity,
-		s3Entity:   s3Entity,
+		taskEntity:            taskEntity,
+		workflowEntity:        workflowEntity,
+		taskAttachmentService: taskAttachmentService,
 	}
 }
 
-func (s *taskService) CreateTask(task request.TaskCreateRequest, img_uuid interface{}) (c model.Task, e error) {
+var (
+	ErrWorkflowStatusNotFound       = apperr.New(apperr.Validation, responses.WorkflowStatusNotFound)
+	ErrWorkflowTransitionNotAllowed = apperr.New(apperr.Validation, responses.WorkflowTransitionNotAllowed)
+)
+
+// GetTaskTitleUnique Get the uniqueness scope of the task titles from .env file (none, user or category)
+func GetTaskTitleUnique() string {
+	unique := os.Getenv("TASK_TITLE_UNIQUE")
+	switch unique {
+	case entity.TitleUniqueNone, entity.TitleUniqueUser, entity.TitleUniqueCategory:
+		return unique
+	}
+
+	// If the environment variable is empty or invalid, use a default value
+	return entity.TitleUniqueUser
+}
+
+// firstStatus returns the user's first status done or not, the new and reopened tasks go to the first status not done
+func (s *taskService) firstStatus(user_id int64, done bool) (model.WorkflowStatus, error) {
+	statuses, err := s.workflowEntity.GetWorkflowStatusList(user_id)
+	if err != nil {
+		return model.WorkflowStatus{}, err
+	}
+
+	status, ok := model.FirstWorkflowStatus(statuses, done)
+	if !ok {
+		return status, ErrWorkflowStatusNotFound
+	}
+
+	return status, nil
+}
+
+// normalizeRRule validates the repeat rule and stores it in normalized form (empty string removes the rule)
+func normalizeRRule(rule *string) (*string, error) {
+	if rule == nil || *rule == "" {
+		return rule, nil
+	}
+
+	r, err := rrule.Parse(*rule)
+	if err != nil {
+		return rule, err
+	}
+
+	normalized := r.String()
+	return &normalized, nil
+}
+
+// parseDue parses the due of the request in the timezone of the user (nil if it is left out).
+// A task without a time (specify_date, or specify_datetime without is_specify_time) keeps its date at 00:00 UTC
+func parseDue(datetime *string, date *string, isSpecifyTime bool, loc *time.Location) (*time.Time, bool, error) {
+	if date != nil && *date != "" {
+		d, err := civilDatetime.ParseDate(*date)
+		if err != nil {
+			return nil, false, apperr.Wrap(apperr.Validation, err)
+		}
+
+		due := d.Time()
+		return &due, false, nil
+	}
+
+	if datetime == nil || *datetime == "" {
+		return nil, isSpecifyTime, nil
+	}
+
+	t, err := civilDatetime.ParseDatetime(*datetime, loc)
+	if err != nil {
+		return nil, isSpecifyTime, apperr.Wrap(apperr.Validation, err)
+	}
+
+	due := t.UTC()
+	if !isSpecifyTime {
+		due = civilDatetime.DateOf(t.In(loc)).Time()
+	}
+
+	return &due, isSpecifyTime, nil
+}
+
+// taskFields maps the request to the fields of the task, the due is parsed on its own
+func taskFields(task interface{}) smapping.Mapped {
+	fields := smapping.MapFields(task)
+	delete(fields, "SpecifyDatetime")
+	delete(fields, "SpecifyDate")
+	delete(fields, "IsSpecifyTime")
+
+	return fields
+}
+
+func (s *taskService) CreateTask(task request.TaskCreateRequest, user_id int64, loc *time.Location) (c model.Task, e error) {
 	taskToCreate := model.Task{}
-	err := smapping.FillStruct(&taskToCreate, smapping.MapFields(&task))
+	err := smapping.FillStruct(&taskToCreate, taskFields(&task))
 	if err != nil {
 		log.Error("CreateTask Failed map : " + err.Error())
 		return taskToCreate, err
 	}
 
-	if task.Image != nil {
-		uuidV4 := ""
-		if img_uuid == nil {
-			uuidV4Ojb, uuidV4Err := uuid.NewV4()
-			if uuidV4Err != nil {
-				return taskToCreate, uuidV4Err
-			}
-			uuidV4 = uuidV4Ojb.String()
-		} else {
-			uuidV4 = fmt.Sprintf("%s", img_uuid)
-		}
+	taskToCreate.SpecifyDatetime, taskToCreate.IsSpecifyTime, err = parseDue(task.SpecifyDatetime, task.SpecifyDate, task.IsSpecifyTime, loc)
+	if err != nil {
+		return taskToCreate, err
+	}
+
+	taskToCreate.RRule, err = normalizeRRule(task.RRule)
+	if err != nil {
+		return taskToCreate, err
+	}
+	taskToCreate.Occurrence = 1
+	if task.ParentID != nil && *task.ParentID == 0 {
+		taskToCreate.ParentID = nil
+	}
 
-		s3Res, s3ResErr := s.s3Entity.FileUpload(task.Image, uuidV4)
-		if s3ResErr != nil {
-			return taskToCreate, s3ResErr
+	// is_complete follows the status, the first status (done or not) by default
+	status, statusErr := s.firstStatus(user_id, task.IsComplete)
+	if task.StatusID != nil {
+		status, statusErr = s.workflowEntity.GetWorkflowStatus(*task.StatusID, user_id)
+		if statusErr == nil && status.ID == 0 {
+			statusErr = ErrWorkflowStatusNotFound
 		}
-		taskToCreate.Img = task.Image.Filename
-		taskToCreate.ImgLink = s3Res.Location
-		taskToCreate.ImgUuid = uuidV4
 	}
+	if statusErr != nil {
+		return taskToCreate, statusErr
+	}
+	taskToCreate.StatusID = &status.ID
+	taskToCreate.IsComplete = status.IsDone
 
-	res, resErr := s.taskEntity.CreateTask(taskToCreate)
+	taskToCreate.UserID = user_id
+	res, resErr := s.taskEntity.CreateTask(taskToCreate, GetTaskTitleUnique())
 	if resErr != nil {
 		return res, resErr
 	}
 
+	// The image is kept as the first attachment of the task
+	if task.Image != nil {
+		attachments, attachmentErr := s.taskAttachmentService.CreateTaskAttachments(res.ID, []*multipart.FileHeader{task.Image})
+		if attachmentErr != nil {
+			// The task never existed for the user, it does not go to the trash
+			purgeErr := s.taskEntity.PurgeTask(res.ID, user_id)
+			if purgeErr != nil {
+				log.Error("CreateTask Failed purge : " + purgeErr.Error())
+			}
+			return res, attachmentErr
+		}
+		res.Attachments = attachments
+	}
+
 	return res, nil
 }
 
-func (s *taskService) UpdateTask(task request.TaskUpdateRequest, id int64, user_id int64, img string, img_uuid interface{}) (c model.Task, e error) {
+func (s *taskService) UpdateTask(task request.TaskUpdateRequest, current model.Task, loc *time.Location) (c model.Task, e error) {
 	taskToUpdate := model.Task{}
-	err := smapping.FillStruct(&taskToUpdate, smapping.MapFields(&task))
+	err := smapping.FillStruct(&taskToUpdate, taskFields(&task))
 	if err != nil {
 		log.Error("CreateTask Failed map : " + err.Error())
 		return taskToUpdate, err
 	}
 
-	if task.Image != nil {
-		uuidV4 := ""
-		if img_uuid == nil {
-			uuidV4Ojb, uuidV4Err := uuid.NewV4()
-			if uuidV4Err != nil {
-				return taskToUpdate, uuidV4Err
+	// The due is updated on its own, is_specify_time can be set to false
+	due, isSpecifyTime, err := parseDue(task.SpecifyDatetime, task.SpecifyDate, task.IsSpecifyTime, loc)
+	if err != nil {
+		return taskToUpdate, err
+	}
+
+	taskToUpdate.RRule, err = normalizeRRule(task.RRule)
+	if err != nil {
+		return taskToUpdate, err
+	}
+
+	// Completing an occurrence of a recurring task is handled after the other fields are updated
+	rule := current.RRule
+	if task.RRule != nil {
+		rule = taskToUpdate.RRule
+	}
+	completeOccurrence := task.IsComplete && !current.IsComplete && rule != nil && *rule != ""
+	// Completing moves the task to the done status, is_complete follows it
+	complete := task.IsComplete && !current.IsComplete && !completeOccurrence
+	taskToUpdate.IsComplete = false
+
+	// The parent is updated on its own, 0 removes it
+	taskToUpdate.ParentID = nil
+
+	var done model.WorkflowStatus
+	if complete || completeOccurrence || (task.IsComplete && task.CompleteChildren) {
+		done, err = s.firstStatus(current.UserID, true)
+		if err != nil {
+			return taskToUpdate, err
+		}
+	}
+
+	taskToUpdate.ID = current.ID
+	taskToUpdate.UserID = current.UserID
+	res := current
+	// The rows are updated together, a failed step leaves the task as it was
+	err = s.taskEntity.Transaction(func(tx entity.TaskEntity) error {
+		// Completing is a move to the done status, the tasks are read again under lock and checked like MoveTask
+		if complete || completeOccurrence {
+			locked, lockErr := tx.LockTask(current.ID, current.UserID)
+			if lockErr != nil {
+				return lockErr
+			}
+			if transitionErr := s.checkTransition(locked, done.ID); transitionErr != nil {
+				return transitionErr
+			}
+		}
+
+		_, updateErr := tx.UpdateTask(taskToUpdate, GetTaskTitleUnique())
+		if updateErr != nil {
+			return updateErr
+		}
+
+		if due != nil {
+			dueErr := tx.UpdateTaskDue(current.ID, current.UserID, due, isSpecifyTime)
+			if dueErr != nil {
+				return dueErr
+			}
+		}
+
+		if task.ParentID != nil {
+			parent := task.ParentID
+			if *parent == 0 {
+				parent = nil
+			}
+			parentErr := tx.UpdateTaskParent(current.ID, current.UserID, parent)
+			if parentErr != nil {
+				return parentErr
+			}
+		}
+
+		if complete {
+			moveErr := tx.MoveTask(current.ID, current.UserID, done, 0)
+			if moveErr != nil {
+				return moveErr
 			}
-			uuidV4 = uuidV4Ojb.String()
-		} else {
-			uuidV4 = fmt.Sprintf("%s", img_uuid)
 		}
 
-		if task.Image.Filename != img {
-			s3RemoveErr := s.s3Entity.FileRemove(img, uuidV4)
-			if s3RemoveErr != nil {
-				return taskToUpdate, s3RemoveErr
+		if task.IsComplete && task.CompleteChildren {
+			children, childrenErr := tx.GetTaskDescendantIDs(current.ID, current.UserID)
+			if childrenErr != nil {
+				return childrenErr
+			}
+
+			// The rows are locked in the order of the ids, as CompleteTasks moves them
+			sort.Slice(children, func(i, j int) bool { return children[i] < children[j] })
+			for _, id := range children {
+				child, lockErr := tx.LockTask(id, current.UserID)
+				if lockErr != nil {
+					return lockErr
+				}
+				if child.IsComplete {
+					continue
+				}
+				if transitionErr := s.checkTransition(child, done.ID); transitionErr != nil {
+					return transitionErr
+				}
+			}
+
+			if completeErr := tx.CompleteTasks(children, current.UserID, done.ID); completeErr != nil {
+				return completeErr
 			}
 		}
-		s3UploadRes, s3UploadResErr := s.s3Entity.FileUpload(task.Image, uuidV4)
-		if s3UploadResErr != nil {
-			return taskToUpdate, s3UploadResErr
+
+		// A rescheduled task has to be reminded again
+		if due != nil && (current.SpecifyDatetime == nil || !due.Equal(*current.SpecifyDatetime) || isSpecifyTime != current.IsSpecifyTime) {
+			resetErr := tx.ResetTaskNotify(current.ID)
+			if resetErr != nil {
+				return resetErr
+			}
+		}
+
+		if completeOccurrence {
+			var occurrenceErr error
+			res, occurrenceErr = s.completeOccurrence(tx, current.ID, current.UserID, loc)
+			return occurrenceErr
 		}
-		taskToUpdate.Img = task.Image.Filename
-		taskToUpdate.ImgLink = s3UploadRes.Location
-		taskToUpdate.ImgUuid = uuidV4
+
+		// The response is the stored task with its relations
+		var getErr error
+		res, getErr = tx.GetTask(current.ID, current.UserID)
+		return getErr
+	})
+	if err != nil {
+		return res, err
 	}
 
-	taskToUpdate.ID = id
-	taskToUpdate.UserID = user_id
-	res, resErr := s.taskEntity.UpdateTask(taskToUpdate)
-	if resErr != nil {
-		return res, resErr
+	// A new image is added as an attachment once the task is updated, the previous ones are kept
+	if task.Image != nil {
+		_, attachmentErr := s.taskAttachmentService.CreateTaskAttachments(current.ID, []*multipart.FileHeader{task.Image})
+		if attachmentErr != nil {
+			return res, attachmentErr
+		}
+
+		return s.taskEntity.GetTask(current.ID, current.UserID)
 	}
 
 	return res, nil
 }
+
+// completeOccurrence records the completed occurrence and generates the next one from the repeat rule.
+// The rule repeats on the days of the user, the date of a task without a time is the same in every timezone
+func (s *taskService) completeOccurrence(tasks entity.TaskEntity, id int64, user_id int64, loc *time.Location) (c model.Task, e error) {
+	task, err := tasks.GetTask(id, user_id)
+	if err != nil {
+		return task, err
+	}
+
+	r, err := rrule.Parse(*task.RRule)
+	if err != nil {
+		return task, err
+	}
+
+	var next *time.Time
+	if task.SpecifyDatetime != nil {
+		current := task.SpecifyDatetime.UTC()
+		if task.IsSpecifyTime {
+			current = current.In(loc)
+		}
+		if t, ok := r.Next(current, task.Occurrence); ok {
+			t = t.UTC()
+			next = &t
+		}
+	}
+
+	// The next occurrence starts over in the first status
+	status, err := s.firstStatus(user_id, next == nil)
+	if err != nil {
+		return task, err
+	}
+
+	return tasks.CompleteOccurrence(task, next, status.ID)
+}
+
+// checkTransition returns ErrWorkflowTransitionNotAllowed if the current status of the task does not allow moving it to the status.
+// The transitions are those of the current status, a task without a status can move anywhere
+func (s *taskService) checkTransition(task model.Task, to int64) error {
+	if task.StatusID == nil {
+		return nil
+	}
+
+	from, err := s.workflowEntity.GetWorkflowStatus(*task.StatusID, task.UserID)
+	if err != nil {
+		return err
+	}
+	if !from.Allows(to) {
+		return ErrWorkflowTransitionNotAllowed
+	}
+
+	return nil
+}
+
+// MoveTask moves the task to the position (from 1, 0 for the end) of the status column, ErrWorkflowTransitionNotAllowed
+// if the current status does not allow it. Moving an incomplete recurring task to a done status completes its occurrence instead
+func (s *taskService) MoveTask(current model.Task, status model.WorkflowStatus, position int, loc *time.Location) (c model.Task, e error) {
+	res := current
+	err := s.taskEntity.Transaction(func(tx entity.TaskEntity) error {
+		// The task is read again under lock, a concurrent move is checked from the status it left the task in
+		task, err := tx.LockTask(current.ID, current.UserID)
+		if err != nil {
+			return err
+		}
+
+		if err := s.checkTransition(task, status.ID); err != nil {
+			return err
+		}
+
+		if status.IsDone && !task.IsComplete && task.RRule != nil && *task.RRule != "" {
+			res, err = s.completeOccurrence(tx, task.ID, task.UserID, loc)
+			return err
+		}
+
+		if err := tx.MoveTask(task.ID, task.UserID, status, position); err != nil {
+			return err
+		}
+		res, err = tx.GetTask(task.ID, task.UserID)
+		return err
+	})
+
+	return res, err
+}
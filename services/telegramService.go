@@ -0,0 +1,162 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"go-todolist/entity"
+	"go-todolist/model"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/log"
+	"go-todolist/utils/responses"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// TelegramService links the Telegram chat of the users
+type TelegramService interface {
+	// CreateLinkCode issues a one-time link code, the previous code of the user is revoked
+	CreateLinkCode(userID uint64) (model.TelegramLink, error)
+
+	// HandleUpdate processes an update sent by Telegram to the bot webhook
+	HandleUpdate(update []byte) error
+
+	// Unlink removes the Telegram chat of the user
+	Unlink(userID uint64) error
+}
+
+type telegramService struct {
+	redisEntity    entity.RedisEntity
+	userEntity     entity.UserEntity
+	telegramEntity entity.TelegramEntity
+}
+
+func NewTelegramService(redisEntity entity.RedisEntity, userEntity entity.UserEntity, telegramEntity entity.TelegramEntity) TelegramService {
+	return &telegramService{
+		redisEntity:    redisEntity,
+		userEntity:     userEntity,
+		telegramEntity: telegramEntity,
+	}
+}
+
+// ErrTelegramWebhookSecretInvalid the secret token of the webhook is missing or does not match TELEGRAM_WEBHOOK_SECRET
+var ErrTelegramWebhookSecretInvalid = apperr.New(apperr.Unauthorized, responses.TelegramWebhookSecretInvalid)
+
+// GetTelegramLinkTTL Get link code TTL from .env file
+func GetTelegramLinkTTL() int {
+	stringTTL := os.Getenv("TELEGRAM_LINK_TTL")
+	if stringTTL == "" {
+		// If the environment variable is empty, use a default value
+		stringTTL = "600"
+	}
+
+	intTTL, _ := strconv.Atoi(stringTTL)
+
+	return intTTL
+}
+
+// telegramLinkKey Get the redis key of a link code
+func telegramLinkKey(code string) string {
+	return "telegram_link:" + code
+}
+
+// telegramLinkUserKey Get the redis key of the current link code of the user
+func telegramLinkUserKey(userID uint64) string {
+	return "telegram_link_user:" + strconv.FormatUint(userID, 10)
+}
+
+func (s *telegramService) CreateLinkCode(userID uint64) (model.TelegramLink, error) {
+	linkTTL := GetTelegramLinkTTL()
+	link := model.TelegramLink{ExpiresIn: linkTTL}
+
+	random := make([]byte, 5)
+	_, err := rand.Read(random)
+	if err != nil {
+		return link, err
+	}
+	link.Code = base32.StdEncoding.EncodeToString(random)
+
+	// Only the latest code of the user is valid
+	previous, err := s.redisEntity.Get(telegramLinkUserKey(userID))
+	if err == nil {
+		s.redisEntity.Del(telegramLinkKey(fmt.Sprintf("%v", previous)))
+	}
+
+	_, err = s.redisEntity.Set(telegramLinkKey(link.Code), strconv.FormatUint(userID, 10), time.Duration(linkTTL)*time.Second)
+	if err != nil {
+		return link, err
+	}
+	_, err = s.redisEntity.Set(telegramLinkUserKey(userID), link.Code, time.Duration(linkTTL)*time.Second)
+	if err != nil {
+		return link, err
+	}
+
+	if botUsername := os.Getenv("TELEGRAM_BOT_USERNAME"); botUsername != "" {
+		link.StartURL = "https://t.me/" + botUsername + "?start=" + link.Code
+	}
+
+	return link, nil
+}
+
+func (s *telegramService) HandleUpdate(update []byte) error {
+	chatID := gjson.GetBytes(update, "message.chat.id")
+	// The command may be addressed to the bot in a group, e.g. /start@BotName CODE
+	args := strings.Fields(gjson.GetBytes(update, "message.text").String())
+	if !chatID.Exists() || len(args) == 0 || (args[0] != "/start" && !strings.HasPrefix(args[0], "/start@")) {
+		// Other updates are ignored
+		return nil
+	}
+
+	code := ""
+	if len(args) > 1 {
+		code = strings.ToUpper(args[1])
+	}
+	if code == "" {
+		return s.reply(chatID.Int(), "Please get a link code from the todo list app first.")
+	}
+
+	// The code can only be used once
+	userIDString, err := s.redisEntity.GetDel(telegramLinkKey(code))
+	if err != nil {
+		return s.reply(chatID.Int(), "The link code is invalid or expired.")
+	}
+
+	userID, err := strconv.ParseUint(userIDString, 10, 64)
+	if err != nil {
+		return err
+	}
+	s.redisEntity.Del(telegramLinkUserKey(userID))
+
+	telegramID := chatID.Int()
+	err = s.userEntity.UpdateTelegramID(userID, &telegramID)
+	if err != nil {
+		log.Error("Failed to link the telegram chat : " + err.Error())
+		return s.reply(telegramID, "Failed to link your account, please try again later.")
+	}
+
+	return s.reply(telegramID, "Your account has been linked, task reminders will be sent here.")
+}
+
+func (s *telegramService) Unlink(userID uint64) error {
+	return s.userEntity.UpdateTelegramID(userID, nil)
+}
+
+// reply sends a message back to the chat, it is skipped when the bot is disabled
+func (s *telegramService) reply(chatID int64, text string) error {
+	if !s.telegramEntity.Enabled() {
+		log.Warn("Telegram reply is skipped : telegram is disabled")
+		return nil
+	}
+
+	err := s.telegramEntity.SendMessage(chatID, text)
+	if err != nil {
+		return errors.New("Failed to reply to the telegram chat : " + err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"go-todolist/entity"
+	"go-todolist/utils/log"
+	"os"
+	"strconv"
+	"time"
+)
+
+// TrashService purges the deleted tasks and categories
+type TrashService interface {
+	// Start purges the trash older than retention on every interval until stop is closed, run it in a goroutine
+	Start(interval time.Duration, retention time.Duration, stop <-chan struct{})
+
+	// PurgeExpired deletes permanently the tasks and categories in the trash since before
+	PurgeExpired(before time.Time) (tasks int64, categories int64, err error)
+
+	// EmptyTrash deletes permanently every task and category in the user's trash
+	EmptyTrash(user_id int64) (tasks int64, categories int64, err error)
+}
+
+type trashService struct {
+	taskEntity     entity.TaskEntity
+	categoryEntity entity.CategoryEntity
+}
+
+func NewTrashService(taskEntity entity.TaskEntity, categoryEntity entity.CategoryEntity) TrashService {
+	return &trashService{
+		taskEntity:     taskEntity,
+		categoryEntity: categoryEntity,
+	}
+}
+
+// GetTrashPurgeInterval Get the purge interval from .env file
+func GetTrashPurgeInterval() time.Duration {
+	stringInterval := os.Getenv("TRASH_PURGE_INTERVAL")
+	if stringInterval == "" {
+		// If the environment variable is empty, use a default value
+		stringInterval = "3600"
+	}
+
+	intInterval, _ := strconv.Atoi(stringInterval)
+	if intInterval < 1 {
+		intInterval = 3600
+	}
+
+	return time.Duration(intInterval) * time.Second
+}
+
+// GetTrashRetention Get how long the deleted records are kept from .env file, 0 disables the automatic purge
+func GetTrashRetention() time.Duration {
+	stringRetention := os.Getenv("TRASH_RETENTION")
+	if stringRetention == "" {
+		// If the environment variable is empty, use a default value (30 days)
+		stringRetention = "2592000"
+	}
+
+	intRetention, _ := strconv.Atoi(stringRetention)
+
+	return time.Duration(intRetention) * time.Second
+}
+
+func (s *trashService) Start(interval time.Duration, retention time.Duration, stop <-chan struct{}) {
+	if retention <= 0 {
+		log.Warn("Trash purge is not started : TRASH_RETENTION is 0")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			// The purge is idempotent, every replica can run it
+			tasks, categories, err := s.PurgeExpired(now.Add(-retention))
+			if err != nil {
+				log.Error("Trash purge failed : " + err.Error())
+				continue
+			}
+			if tasks > 0 || categories > 0 {
+				log.Infof("Trash purge : %d tasks, %d categories", tasks, categories)
+			}
+		}
+	}
+}
+
+func (s *trashService) PurgeExpired(before time.Time) (tasks int64, categories int64, err error) {
+	// The categories first, their tasks deleted with them are removed by the foreign key
+	categories, err = s.categoryEntity.PurgeExpiredCategories(before)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	tasks, err = s.taskEntity.PurgeExpiredTasks(before)
+	return tasks, categories, err
+}
+
+func (s *trashService) EmptyTrash(user_id int64) (tasks int64, categories int64, err error) {
+	categories, err = s.categoryEntity.PurgeTrashedCategories(user_id)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	tasks, err = s.taskEntity.PurgeTrashedTasks(user_id)
+	return tasks, categories, err
+}
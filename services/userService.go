@@ -4,7 +4,11 @@ import (
 	"go-todolist/entity"
 	"go-todolist/model"
 	"go-todolist/request"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/civilDatetime"
+	"go-todolist/utils/i18n"
 	"go-todolist/utils/log"
+	"go-todolist/utils/responses"
 
 	"github.com/mashingan/smapping"
 	"golang.org/x/crypto/bcrypt"
@@ -13,14 +17,31 @@ import (
 // UserService is a contract about some user service can do
 type UserService interface {
 	// VerifyCredential is verify user credential
-	VerifyCredential(email string, password string) interface{}
+	VerifyCredential(email string, password string) (model.User, error)
 
 	// CreateUser is insert user to db and return user model to caller function
-	CreateUser(user request.RegisterRequest) model.User
+	CreateUser(user request.RegisterRequest) (model.User, error)
+
+	// GetProfile is get the user with the profile settings
+	GetProfile(id uint64) (model.User, error)
+
+	// UpdateProfile is update the profile settings of the user
+	UpdateProfile(id uint64, input request.ProfileUpdateRequest) (model.User, error)
 
 	// FindByEmail(email string, password string) model.User
 }
 
+var (
+	// ErrInvalidCredential the email does not exist or the password does not match
+	ErrInvalidCredential = apperr.New(apperr.Unauthorized, responses.InvalidCredential)
+
+	// ErrLocaleNotSupported the locale has no message catalog
+	ErrLocaleNotSupported = apperr.New(apperr.Validation, responses.LocaleNotSupported)
+
+	// ErrTimezoneInvalid the timezone is not in the IANA database
+	ErrTimezoneInvalid = apperr.New(apperr.Validation, responses.TimezoneInvalid)
+)
+
 // Create a new authService with the given userEntity.
 type userService struct {
 	userEntity entity.UserEntity
@@ -31,8 +52,8 @@ func NewUserService(userEntity entity.UserEntity) UserService {
 	return &userService{userEntity: userEntity}
 }
 
-// VerifyCredential is verify user credential and return user model to caller function
-func (s *userService) VerifyCredential(email string, password string) interface{} {
+// VerifyCredential is verify user credential and return user model to caller function, ErrInvalidCredential if the credential is incorrect
+func (s *userService) VerifyCredential(email string, password string) (model.User, error) {
 	// Verify user credential and return user model to caller function
 	res := s.userEntity.VerifyCredential(email)
 	// if res is user model then return user model to caller function
@@ -42,19 +63,19 @@ func (s *userService) VerifyCredential(email string, password string) interface{
 		// if email is matched and password is matched then return user model to caller function
 		if v.Email == email && comparedPassword {
 			// return user model to caller function
-			return res
+			return v, nil
 		}
 
-		// return false if email is not matched or password is not matched
-		return false
+		// return error if email is not matched or password is not matched
+		return model.User{}, ErrInvalidCredential
 	}
 
-	// return false if res is not user model
-	return false
+	// return error if res is not user model
+	return model.User{}, ErrInvalidCredential
 }
 
-// CreateUser is insert user to db and return user model to caller function
-func (s *userService) CreateUser(user request.RegisterRequest) model.User {
+// CreateUser is insert user to db and return user model to caller function, entity.ErrEmailAlreadyExists if the email is registered
+func (s *userService) CreateUser(user request.RegisterRequest) (model.User, error) {
 	// create user model
 	userToCreate := model.User{}
 
@@ -62,18 +83,60 @@ func (s *userService) CreateUser(user request.RegisterRequest) model.User {
 	err := smapping.FillStruct(&userToCreate, smapping.MapFields(&user))
 	if err != nil {
 		log.Error("Failed map : " + err.Error())
+		return userToCreate, err
 	}
 
 	findByEmail := s.userEntity.FindByEmail(user.Email)
 
 	if findByEmail.ID == 0 {
 		// insert user to db and return user model to caller function
-		res := s.userEntity.InsertUser(userToCreate)
-		// return user model to caller function
-		return res
+		return s.userEntity.InsertUser(userToCreate)
+	}
+
+	return userToCreate, entity.ErrEmailAlreadyExists
+}
+
+// GetProfile is get the user with the profile settings, apperr.ErrRecordNotFound if the user no longer exists
+func (s *userService) GetProfile(id uint64) (model.User, error) {
+	user := s.userEntity.FindByID(id)
+	if user.ID == 0 {
+		return user, apperr.ErrRecordNotFound
+	}
+
+	return user, nil
+}
+
+// UpdateProfile is update the given profile settings of the user, ErrLocaleNotSupported if the locale has no catalog
+// and ErrTimezoneInvalid if the timezone is unknown. An empty locale or timezone clears the preference
+func (s *userService) UpdateProfile(id uint64, input request.ProfileUpdateRequest) (model.User, error) {
+	values := map[string]interface{}{}
+	if input.Locale != nil {
+		values["locale"] = nil
+		if *input.Locale != "" {
+			locale := i18n.Match(*input.Locale)
+			if locale == "" {
+				return model.User{}, ErrLocaleNotSupported
+			}
+			values["locale"] = locale
+		}
+	}
+	if input.Timezone != nil {
+		values["timezone"] = nil
+		if *input.Timezone != "" {
+			if _, ok := civilDatetime.LoadLocation(*input.Timezone); !ok {
+				return model.User{}, ErrTimezoneInvalid
+			}
+			values["timezone"] = *input.Timezone
+		}
+	}
+
+	if len(values) > 0 {
+		if err := s.userEntity.UpdateProfile(id, values); err != nil {
+			return model.User{}, err
+		}
 	}
 
-	return userToCreate
+	return s.GetProfile(id)
 }
 
 // func (s *userService) FindByEmail(email string) model.User {
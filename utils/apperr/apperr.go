@@ -0,0 +1,74 @@
+package apperr
+
+import (
+	"errors"
+	"go-todolist/utils/i18n"
+	"go-todolist/utils/responses"
+)
+
+// Kind is the category of a domain error, the error middleware maps it to the HTTP status
+type Kind int
+
+const (
+	// Unexpected failure (database, bug...)
+	Internal Kind = iota
+	// The record does not exist or is not visible to the user
+	NotFound
+	// The request conflicts with the current state (duplicate...)
+	Conflict
+	// The input is invalid
+	Validation
+	// The credential is missing or invalid
+	Unauthorized
+	// The user is not allowed to do it
+	Forbidden
+	// An external service (storage, Telegram...) failed
+	Upstream
+)
+
+// Error is a domain error returned by the entities and the services.
+// Code is the error code of utils/responses, without a code the message of the cause is returned
+type Error struct {
+	Kind Kind
+	Code int
+	Err  error
+}
+
+// New creates an error with the message of the responses code, it is meant to be declared once as a package variable
+func New(kind Kind, code int) *Error {
+	return &Error{Kind: kind, Code: code}
+}
+
+// Wrap gives a kind to an error, its message is kept
+func Wrap(kind Kind, err error) *Error {
+	return &Error{Kind: kind, Err: err}
+}
+
+// Error is the English message, the error middleware responds with the one of the request locale
+func (e *Error) Error() string {
+	if e.Code != 0 || e.Err == nil {
+		return responses.Message(i18n.English, e.Code)
+	}
+
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// As finds the domain error in the chain of err
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+
+	return nil, false
+}
+
+// The errors shared by the controllers
+var (
+	ErrIdInvalid      = New(Validation, responses.IdInvalid)
+	ErrRecordNotFound = New(NotFound, responses.RecordNotFound)
+)
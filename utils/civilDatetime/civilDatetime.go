@@ -1,6 +1,7 @@
 package civilDatetime
 
 import (
+	"os"
 	"strings"
 	"time"
 )
@@ -8,34 +9,134 @@ import (
 type CivilDate time.Time
 type CivilTime time.Time
 
+// Layouts of the civil date and time
+const (
+	DateLayout = "2006-01-02"
+	TimeLayout = "15:04:05"
+)
+
+// Layouts of the datetimes without a zone, they are in the timezone of the user
+var localLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+}
+
+// GetDefaultTimezone Get the IANA timezone of the users without one from .env file
+func GetDefaultTimezone() string {
+	timezone := os.Getenv("DEFAULT_TIMEZONE")
+
+	// If the environment variable is empty or not a timezone, use a default value
+	if _, ok := LoadLocation(timezone); !ok {
+		return "UTC"
+	}
+
+	return timezone
+}
+
+// DefaultLocation is the location of GetDefaultTimezone
+func DefaultLocation() *time.Location {
+	loc, _ := LoadLocation(GetDefaultTimezone())
+	return loc
+}
+
+// LoadLocation loads the IANA timezone (e.g. Asia/Taipei), false if it is empty or unknown.
+// Local is refused, the timezone of the server is not the one of the user
+func LoadLocation(name string) (*time.Location, bool) {
+	if name == "" || name == "Local" {
+		return time.UTC, false
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC, false
+	}
+
+	return loc, true
+}
+
+// ParseDatetime parses an RFC 3339 datetime (e.g. 2006-01-02T15:04:05+08:00), or one without a zone
+// (e.g. 2006-01-02 15:04:05) in the location
+func ParseDatetime(value string, loc *time.Location) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err == nil {
+		return t, nil
+	}
+
+	for _, layout := range localLayouts {
+		if local, localErr := time.ParseInLocation(layout, value, loc); localErr == nil {
+			return local, nil
+		}
+	}
+
+	return t, err
+}
+
+// ParseDate parses a date (2006-01-02)
+func ParseDate(value string) (CivilDate, error) {
+	t, err := time.Parse(DateLayout, value)
+	return CivilDate(t), err
+}
+
 // 以下程式碼主要用於 golnag 日期型態問題，
 // 對於只需要單純的 Y-m-d(date) or H:i:s(time) 這類型的 db type，
 // 因此會需要有辦法在 response 時轉換一次格式再回傳給使用者
-func (c *CivilDate) UnmarshalJSONDate(b []byte) error {
+
+// DateOf is the date of the time in its location
+func DateOf(t time.Time) CivilDate {
+	return CivilDate(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC))
+}
+
+// Time is the start of the date in UTC, the way a date without a time is stored
+func (c CivilDate) Time() time.Time {
+	return time.Time(c)
+}
+
+// In is the start of the date in the location
+func (c CivilDate) In(loc *time.Location) time.Time {
+	t := time.Time(c)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+func (c CivilDate) String() string {
+	return time.Time(c).Format(DateLayout)
+}
+
+func (c *CivilDate) UnmarshalJSON(b []byte) error {
 	value := strings.Trim(string(b), `"`) //get rid of "
 	if value == "" || value == "null" {
 		return nil
 	}
 
-	t, err := time.Parse("2006-01-02", value) //parse date
+	d, err := ParseDate(value) //parse date
 	if err != nil {
 		return err
 	}
-	*c = CivilDate(t) //set result using the pointer
+	*c = d //set result using the pointer
 	return nil
 }
 
-func (c CivilDate) MarshalJSONDate() ([]byte, error) {
-	return []byte(`"` + time.Time(c).Format("2006-01-02") + `"`), nil
+func (c CivilDate) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + c.String() + `"`), nil
 }
 
-func (c *CivilTime) UnmarshalJSONTime(b []byte) error {
+// TimeOf is the time of the day of the time in its location
+func TimeOf(t time.Time) CivilTime {
+	return CivilTime(time.Date(0, 1, 1, t.Hour(), t.Minute(), t.Second(), 0, time.UTC))
+}
+
+func (c CivilTime) String() string {
+	return time.Time(c).Format(TimeLayout)
+}
+
+func (c *CivilTime) UnmarshalJSON(b []byte) error {
 	value := strings.Trim(string(b), `"`) //get rid of "
 	if value == "" || value == "null" {
 		return nil
 	}
 
-	t, err := time.Parse("15:04:05", value) //parse time
+	t, err := time.Parse(TimeLayout, value) //parse time
 	if err != nil {
 		return err
 	}
@@ -43,27 +144,6 @@ func (c *CivilTime) UnmarshalJSONTime(b []byte) error {
 	return nil
 }
 
-func (c CivilTime) MarshalJSONTime() ([]byte, error) {
-	return []byte(`"` + time.Time(c).Format("15:04:05") + `"`), nil
-}
-
-// Another version
-// func (c *CivilTime) UnmarshalJSON(data []byte) error {
-// 	if string(data) == "null" {
-// 		return nil
-// 	}
-// 	var t time.Time
-// 	err := json.Unmarshal(data, &t)
-// 	if err != nil {
-// 		return err
-// 	}
-// 	*c = CivilTime(t)
-// 	return nil
-// }
-
-// func (c CivilTime) MarshalJSON() ([]byte, error) {
-// 	if time.Time(c).IsZero() {
-// 		return []byte("123"), nil
-// 	}
-// 	return []byte(`"` + time.Time(c).Format("2006-01-02") + `"`), nil
-// }
+func (c CivilTime) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + c.String() + `"`), nil
+}
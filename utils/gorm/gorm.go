@@ -24,8 +24,9 @@ func InitMySQL() *gorm.DB {
 	dbHost := os.Getenv("DB_HOST")
 	dbName := os.Getenv("DB_NAME")
 
-	// Create the connection string
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:3306)/%s?charset=utf8mb4&parseTime=True&loc=Local", dbUser, dbPassword, dbHost, dbName)
+	// Create the connection string, the datetimes are stored in UTC whatever the timezone of the server
+	// (the session time_zone '+00:00' for NOW() and the timestamp columns)
+	dsn := fmt.Sprintf("%s:%s@tcp(%s:3306)/%s?charset=utf8mb4&parseTime=True&loc=UTC&time_zone=%%27%%2B00%%3A00%%27", dbUser, dbPassword, dbHost, dbName)
 
 	// Open connection to the database
 	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{
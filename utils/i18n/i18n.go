@@ -0,0 +1,111 @@
+package i18n
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The supported locales
+const (
+	English            = "en"
+	TraditionalChinese = "zh-TW"
+)
+
+var Locales = []string{English, TraditionalChinese}
+
+// The locale of the languages without a catalog of their own region, e.g. zh-HK and zh-Hant use zh-TW
+var languages = map[string]string{
+	"en": English,
+	"zh": TraditionalChinese,
+}
+
+// GetDefaultLocale Get the locale used when none is requested from .env file, the last of the fallback chain
+func GetDefaultLocale() string {
+	locale := Match(os.Getenv("DEFAULT_LOCALE"))
+
+	// If the environment variable is empty or not supported, use a default value
+	if locale == "" {
+		return English
+	}
+
+	return locale
+}
+
+// Match returns the supported locale of the language tag (e.g. zh-tw, zh_TW, zh-Hant-HK), empty if none
+func Match(tag string) string {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if tag == "" {
+		return ""
+	}
+
+	for _, locale := range Locales {
+		if strings.ToLower(locale) == tag {
+			return locale
+		}
+	}
+
+	return languages[strings.SplitN(tag, "-", 2)[0]]
+}
+
+// Negotiate picks the supported locale of an Accept-Language header by the quality values, empty if none
+func Negotiate(acceptLanguage string) string {
+	type candidate struct {
+		locale  string
+		quality float64
+	}
+
+	candidates := []candidate{}
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		quality := 1.0
+		if params = strings.TrimSpace(params); strings.HasPrefix(params, "q=") {
+			parsed, err := strconv.ParseFloat(strings.TrimPrefix(params, "q="), 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		if locale := Match(tag); locale != "" && quality > 0 {
+			candidates = append(candidates, candidate{locale, quality})
+		}
+	}
+
+	// The first of the preferred ones
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	return candidates[0].locale
+}
+
+// Resolve picks the locale of a request, the preference of the user first, then the Accept-Language header
+// and finally the default locale
+func Resolve(preference *string, acceptLanguage string) string {
+	if preference != nil {
+		if locale := Match(*preference); locale != "" {
+			return locale
+		}
+	}
+	if locale := Negotiate(acceptLanguage); locale != "" {
+		return locale
+	}
+
+	return GetDefaultLocale()
+}
+
+// Translate looks up the key in the catalogs of the locale, then of the default locale and finally of English
+func Translate[K comparable](catalogs map[string]map[K]string, locale string, key K) (string, bool) {
+	for _, fallback := range []string{locale, GetDefaultLocale(), English} {
+		if message, ok := catalogs[fallback][key]; ok {
+			return message, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,281 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"sort"
+)
+
+// Processed content types
+const (
+	JPEG = "image/jpeg"
+	PNG  = "image/png"
+	GIF  = "image/gif"
+)
+
+// Refuse to decode images larger than this (decompression bombs)
+const maxPixels = 50_000_000
+
+const jpegQuality = 90
+
+var (
+	ErrUnsupported   = errors.New("Image type is not supported.")
+	ErrImageTooLarge = errors.New("Image dimensions are too large.")
+)
+
+// Result is a processed image, the original without metadata and its thumbnails by size
+type Result struct {
+	Original   []byte
+	Thumbnails map[int][]byte
+}
+
+// Supported reports whether images of the content type are processed
+func Supported(contentType string) bool {
+	return contentType == JPEG || contentType == PNG || contentType == GIF
+}
+
+// Process strips the metadata (EXIF, GPS, text chunks, GIF comments and application extensions such as XMP) of the image and creates the thumbnails,
+// each thumbnail fits in a size x size box and is never larger than the original
+func Process(data []byte, contentType string, sizes []int) (*Result, error) {
+	if !Supported(contentType) {
+		return nil, ErrUnsupported
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width*config.Height > maxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	res := &Result{Thumbnails: map[int][]byte{}}
+	switch contentType {
+	case JPEG:
+		// The orientation is lost with the EXIF, so it is applied to the pixels
+		img = orient(img, exifOrientation(data))
+		res.Original, err = encode(img, contentType)
+	case PNG:
+		res.Original, err = encode(img, contentType)
+	default:
+		res.Original, err = encodeGIF(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// From the largest to the smallest, each thumbnail is resized from the previous one
+	descending := append([]int{}, sizes...)
+	sort.Sort(sort.Reverse(sort.IntSlice(descending)))
+	for _, size := range descending {
+		img = Resize(img, size)
+		thumbnail, err := encode(img, contentType)
+		if err != nil {
+			return nil, err
+		}
+		res.Thumbnails[size] = thumbnail
+	}
+
+	return res, nil
+}
+
+func encode(img image.Image, contentType string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch contentType {
+	case JPEG:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	case PNG:
+		err = png.Encode(&buf, img)
+	case GIF:
+		err = gif.Encode(&buf, img, nil)
+	default:
+		err = ErrUnsupported
+	}
+
+	return buf.Bytes(), err
+}
+
+// encodeGIF encodes the frames of the GIF again, the comment and application extensions (XMP...) are dropped
+// and the animation is kept: the frames, their delays and disposal, the loop count
+func encodeGIF(data []byte) ([]byte, error) {
+	g, err := gif.DecodeAll(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	// Every frame is decoded, the limit counts them all
+	pixels := 0
+	for _, frame := range g.Image {
+		pixels += frame.Bounds().Dx() * frame.Bounds().Dy()
+	}
+	if pixels > maxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	var buf bytes.Buffer
+	err = gif.EncodeAll(&buf, g)
+
+	return buf.Bytes(), err
+}
+
+// Resize scales the image down to fit in a size x size box, averaging the source pixels of each target pixel
+func Resize(img image.Image, size int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= size && h <= size {
+		return img
+	}
+
+	dw, dh := size, h*size/w
+	if h > w {
+		dw, dh = w*size/h, size
+	}
+	if dw < 1 {
+		dw = 1
+	}
+	if dh < 1 {
+		dh = 1
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		sy0, sy1 := bounds.Min.Y+y*h/dh, bounds.Min.Y+(y+1)*h/dh
+		for x := 0; x < dw; x++ {
+			sx0, sx1 := bounds.Min.X+x*w/dw, bounds.Min.X+(x+1)*w/dw
+
+			var r, g, b, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					c := color.NRGBA64Model.Convert(img.At(sx, sy)).(color.NRGBA64)
+					// Weight by alpha, so that transparent pixels do not darken the edges
+					r += uint64(c.R) * uint64(c.A)
+					g += uint64(c.G) * uint64(c.A)
+					b += uint64(c.B) * uint64(c.A)
+					a += uint64(c.A)
+					n++
+				}
+			}
+			if a > 0 {
+				dst.SetNRGBA(x, y, color.NRGBA{R: uint8(r / a >> 8), G: uint8(g / a >> 8), B: uint8(b / a >> 8), A: uint8(a / n >> 8)})
+			}
+		}
+	}
+
+	return dst
+}
+
+// orient applies the EXIF orientation (1-8) to the image
+func orient(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	dw, dh := w, h
+	// 5-8 are rotated by 90 degrees
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // mirrored horizontally
+				dx, dy = w-1-x, y
+			case 3: // rotated 180
+				dx, dy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				dx, dy = x, h-1-y
+			case 5: // mirrored horizontally, rotated 270 clockwise
+				dx, dy = y, x
+			case 6: // rotated 90 clockwise
+				dx, dy = h-1-y, x
+			case 7: // mirrored horizontally, rotated 90 clockwise
+				dx, dy = h-1-y, w-1-x
+			case 8: // rotated 270 clockwise
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+		}
+	}
+
+	return dst
+}
+
+// exifOrientation reads the orientation tag of the EXIF segment of a JPEG, 1 (normal) if there is none
+func exifOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		// Start of scan, the metadata segments are before it
+		if marker == 0xDA || length < 2 || i+2+length > len(data) {
+			return 1
+		}
+
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && len(segment) > 6 && string(segment[:6]) == "Exif\x00\x00" {
+			return tiffOrientation(segment[6:])
+		}
+
+		i += 2 + length
+	}
+
+	return 1
+}
+
+// tiffOrientation reads the orientation tag (0x0112) of IFD0
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset+2 > len(tiff) {
+		return 1
+	}
+
+	entries := int(order.Uint16(tiff[offset:]))
+	for e := 0; e < entries; e++ {
+		entry := offset + 2 + e*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+
+	return 1
+}
@@ -0,0 +1,79 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+)
+
+// gifWithExtensions is an animated GIF of two frames carrying a comment extension and an XMP application extension
+func gifWithExtensions(t *testing.T) []byte {
+	palette := color.Palette{color.Black, color.White}
+	g := &gif.GIF{LoopCount: 0}
+	for i := 0; i < 2; i++ {
+		frame := image.NewPaletted(image.Rect(0, 0, 4, 4), palette)
+		frame.SetColorIndex(i, i, 1)
+		g.Image = append(g.Image, frame)
+		g.Delay = append(g.Delay, 10)
+	}
+
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, g); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	// After the header, the logical screen descriptor and the global color table
+	offset := 13
+	if flags := data[10]; flags&0x80 != 0 {
+		offset += 3 << (flags&0x07 + 1)
+	}
+
+	var extensions []byte
+	comment := "secret comment"
+	extensions = append(extensions, 0x21, 0xFE, byte(len(comment)))
+	extensions = append(extensions, comment...)
+	extensions = append(extensions, 0x00)
+	xmp := "<x:xmpmeta>secret xmp</x:xmpmeta>"
+	extensions = append(extensions, 0x21, 0xFF, 0x0B)
+	extensions = append(extensions, "XMP DataXMP"...)
+	extensions = append(extensions, byte(len(xmp)))
+	extensions = append(extensions, xmp...)
+	extensions = append(extensions, 0x00)
+
+	return append(append(append([]byte{}, data[:offset]...), extensions...), data[offset:]...)
+}
+
+func TestProcessGIFDropsExtensions(t *testing.T) {
+	data := gifWithExtensions(t)
+	if !bytes.Contains(data, []byte("secret comment")) || !bytes.Contains(data, []byte("secret xmp")) {
+		t.Fatal("the test GIF does not carry the extensions")
+	}
+	if _, err := gif.DecodeAll(bytes.NewReader(data)); err != nil {
+		t.Fatalf("the test GIF is invalid: %v", err)
+	}
+
+	res, err := Process(data, GIF, []int{2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, secret := range []string{"secret comment", "secret xmp", "XMP DataXMP"} {
+		if bytes.Contains(res.Original, []byte(secret)) {
+			t.Errorf("the original still contains %q", secret)
+		}
+	}
+
+	g, err := gif.DecodeAll(bytes.NewReader(res.Original))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(g.Image) != 2 || g.Delay[1] != 10 {
+		t.Errorf("the animation is not kept: %d frames, delays %v", len(g.Image), g.Delay)
+	}
+	if len(res.Thumbnails[2]) == 0 {
+		t.Error("the thumbnail is missing")
+	}
+}
@@ -0,0 +1,292 @@
+package paginator
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/log"
+	"go-todolist/utils/responses"
+	"os"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// Keyset paging structure, the pages are fetched after (or before) the last row of the previous page
+// instead of an OFFSET, so that the rows inserted while paging are neither skipped nor repeated
+type CursorPage[T any] struct {
+	PageLimit  int64  `json:"pageLimit"`
+	Total      *int64 `json:"total,omitempty"` // Data count, only when requested
+	NextCursor string `json:"next_cursor"`     // Empty on the last page
+	PrevCursor string `json:"prev_cursor"`     // Empty on the first page
+	Data       []T    `json:"data"`
+}
+
+var ErrCursorInvalid = apperr.New(apperr.Validation, responses.CursorInvalid)
+
+// Directions of a cursor
+const (
+	cursorNext = "next"
+	cursorPrev = "prev"
+)
+
+// cursor is the position of a page boundary, values of the order columns of the boundary row
+type cursor struct {
+	Direction string            `json:"d"`
+	Order     string            `json:"o"`
+	Values    []json.RawMessage `json:"v"`
+}
+
+// First set up the query, then call this func. The order must not contain expressions, the key (a unique column, e.g. tasks.id)
+// is appended to it so that every row has a distinct position. An empty cursor gets the first page
+func (page *CursorPage[T]) SelectCursor(query *gorm.DB, order clause.OrderBy, key string, encoded string, withTotal bool) (e error) {
+	var model T
+	switch {
+	// Limit the maximum number of pagination
+	case page.PageLimit > 100:
+		page.PageLimit = 100
+	// Set the default number of pagination
+	case page.PageLimit <= 0:
+		page.PageLimit = 10
+	}
+
+	columns := withKey(order.Columns, key)
+	orderKey := orderSignature(columns)
+
+	if err := query.Statement.Parse(&model); err != nil {
+		return err
+	}
+	fields := make([]*schema.Field, len(columns))
+	for i, column := range columns {
+		fields[i] = query.Statement.Schema.LookUpField(columnName(column.Column.Name))
+		if fields[i] == nil {
+			return errors.New("Cursor column " + column.Column.Name + " is not a field.")
+		}
+	}
+
+	var position *cursor
+	if encoded != "" {
+		position, e = decodeCursor(encoded)
+		if e != nil || position.Order != orderKey || len(position.Values) != len(columns) {
+			return ErrCursorInvalid
+		}
+	}
+
+	if withTotal {
+		var total int64
+		if e = query.Model(&model).Count(&total).Error; e != nil {
+			return e
+		}
+		page.Total = &total
+	}
+
+	// A previous page is fetched in the reverse order, then put back in order
+	backward := position != nil && position.Direction == cursorPrev
+	if backward {
+		columns = reverse(columns)
+	}
+
+	if position != nil {
+		values := make([]interface{}, len(fields))
+		for i, field := range fields {
+			value := reflect.New(field.FieldType)
+			if err := json.Unmarshal(position.Values[i], value.Interface()); err != nil {
+				return ErrCursorInvalid
+			}
+			values[i] = value.Elem().Interface()
+			if value.Elem().Kind() == reflect.Ptr && value.Elem().IsNil() {
+				values[i] = nil
+			}
+		}
+
+		sql, vars := after(query.Statement, columns, values)
+		query.Where(sql, vars...)
+	}
+
+	// One more row tells whether there is a page after this one
+	e = query.Model(&model).Clauses(clause.OrderBy{Columns: columns}).Limit(int(page.PageLimit + 1)).Find(&page.Data).Error
+	if e != nil {
+		return e
+	}
+
+	more := len(page.Data) > int(page.PageLimit)
+	if more {
+		page.Data = page.Data[:page.PageLimit]
+	}
+	if backward {
+		for i, j := 0, len(page.Data)-1; i < j; i, j = i+1, j-1 {
+			page.Data[i], page.Data[j] = page.Data[j], page.Data[i]
+		}
+	}
+	if len(page.Data) == 0 {
+		page.Data = []T{}
+		return nil
+	}
+
+	hasNext, hasPrev := more, position != nil
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+	if hasNext {
+		page.NextCursor, e = encodeCursor(cursorNext, orderKey, fields, page.Data[len(page.Data)-1])
+		if e != nil {
+			return e
+		}
+	}
+	if hasPrev {
+		page.PrevCursor, e = encodeCursor(cursorPrev, orderKey, fields, page.Data[0])
+	}
+
+	return e
+}
+
+// withKey appends the key column (ascending) to the order, unless the order already contains it
+func withKey(columns []clause.OrderByColumn, key string) []clause.OrderByColumn {
+	keyed := append([]clause.OrderByColumn{}, columns...)
+	for _, column := range keyed {
+		if column.Column.Name == key {
+			return keyed
+		}
+	}
+
+	return append(keyed, clause.OrderByColumn{Column: clause.Column{Name: key}})
+}
+
+func reverse(columns []clause.OrderByColumn) []clause.OrderByColumn {
+	reversed := make([]clause.OrderByColumn, len(columns))
+	for i, column := range columns {
+		column.Desc = !column.Desc
+		reversed[i] = column
+	}
+
+	return reversed
+}
+
+// orderSignature identifies the order of a cursor, a cursor of another order is refused
+func orderSignature(columns []clause.OrderByColumn) string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Column.Name
+		if column.Desc {
+			names[i] = "-" + names[i]
+		}
+	}
+
+	return strings.Join(names, ",")
+}
+
+// columnName returns the column without its table, "tasks.id" is "id"
+func columnName(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i+1:]
+	}
+
+	return name
+}
+
+// after returns the condition of the rows after the values in the order of the columns.
+// NULL is the smallest value (MySQL), first in ascending order and last in descending order
+func after(stmt *gorm.Statement, columns []clause.OrderByColumn, values []interface{}) (string, []interface{}) {
+	column := stmt.Quote(columns[0].Column)
+	value := values[0]
+	last := len(columns) == 1
+
+	var sql string
+	var vars []interface{}
+	switch {
+	case !columns[0].Desc && value != nil:
+		sql, vars = column+" > ?", []interface{}{value}
+	case !columns[0].Desc:
+		sql = column + " IS NOT NULL"
+	case value != nil:
+		sql, vars = "("+column+" < ? OR "+column+" IS NULL)", []interface{}{value}
+	}
+	if last {
+		if sql == "" {
+			return "1 = 0", nil
+		}
+		return sql, vars
+	}
+
+	// Ties on this column are ordered by the next ones
+	rest, restVars := after(stmt, columns[1:], values[1:])
+	tie, tieVars := column+" IS NULL AND ("+rest+")", restVars
+	if value != nil {
+		tie, tieVars = column+" = ? AND ("+rest+")", append([]interface{}{value}, restVars...)
+	}
+	if sql == "" {
+		return tie, tieVars
+	}
+
+	return "(" + sql + " OR (" + tie + "))", append(vars, tieVars...)
+}
+
+// cursorSigningKey is the signing key of the cursors, see InitCursor
+var cursorSigningKey []byte
+
+// InitCursor Get the signing key of the cursors from .env file, the cursors have their own key and it is required
+func InitCursor() {
+	key := os.Getenv("CURSOR_SIGNING_KEY")
+	if key == "" {
+		log.Panic("CURSOR_SIGNING_KEY is required to sign the list cursors")
+	}
+
+	cursorSigningKey = []byte(key)
+}
+
+func sign(payload string) string {
+	mac := hmac.New(sha256.New, cursorSigningKey)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// encodeCursor returns the opaque cursor of the row, the values are signed so that the client can not alter the query
+func encodeCursor[T any](direction string, order string, fields []*schema.Field, row T) (string, error) {
+	position := cursor{Direction: direction, Order: order, Values: make([]json.RawMessage, len(fields))}
+	value := reflect.ValueOf(&row)
+	for i, field := range fields {
+		fieldValue, _ := field.ValueOf(context.Background(), value)
+		raw, err := json.Marshal(fieldValue)
+		if err != nil {
+			return "", err
+		}
+		position.Values[i] = raw
+	}
+
+	data, err := json.Marshal(position)
+	if err != nil {
+		return "", err
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + sign(payload), nil
+}
+
+func decodeCursor(encoded string) (*cursor, error) {
+	payload, signature, ok := strings.Cut(encoded, ".")
+	if !ok || !hmac.Equal([]byte(sign(payload)), []byte(signature)) {
+		return nil, ErrCursorInvalid
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrCursorInvalid
+	}
+
+	position := &cursor{}
+	if err := json.Unmarshal(data, position); err != nil {
+		return nil, ErrCursorInvalid
+	}
+	if position.Direction != cursorNext && position.Direction != cursorPrev {
+		return nil, ErrCursorInvalid
+	}
+
+	return position, nil
+}
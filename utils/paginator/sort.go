@@ -0,0 +1,40 @@
+package paginator
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm/clause"
+)
+
+// SortColumns maps the sort fields accepted from the request to their columns
+type SortColumns map[string]string
+
+// Sort parses a sort parameter into an ORDER BY of the whitelisted columns,
+// the fields are separated by commas and prefixed with "-" for descending, e.g. "-priority,specify_datetime"
+func Sort(sort string, columns SortColumns) (clause.OrderBy, error) {
+	orderBy := clause.OrderBy{}
+	if strings.TrimSpace(sort) == "" {
+		return orderBy, nil
+	}
+
+	seen := map[string]bool{}
+	for _, field := range strings.Split(sort, ",") {
+		field = strings.TrimSpace(field)
+		desc := strings.HasPrefix(field, "-")
+		field = strings.TrimPrefix(field, "-")
+
+		column, ok := columns[field]
+		if !ok {
+			return orderBy, fmt.Errorf("Sort field %q is not allowed.", field)
+		}
+		if seen[field] {
+			return orderBy, fmt.Errorf("Sort field %q is repeated.", field)
+		}
+		seen[field] = true
+
+		orderBy.Columns = append(orderBy.Columns, clause.OrderByColumn{Column: clause.Column{Name: column}, Desc: desc})
+	}
+
+	return orderBy, nil
+}
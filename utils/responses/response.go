@@ -1,6 +1,10 @@
 package responses
 
-import "strings"
+import (
+	"go-todolist/utils/i18n"
+	"go-todolist/utils/validation"
+	"strings"
+)
 
 const (
 	// 4xx
@@ -11,13 +15,41 @@ const (
 	EmailNotExists                         = 400005
 	FailedToGetStateToken                  = 400006
 	IdInvalid                              = 400007
-	ImageFileNameLimitOf100                = 400008
-	ImageFileSizeLimitOf5MB                = 400009
+	FileNameTooLong                        = 400008
+	FileTooLarge                           = 400009
+	CategoryNotFound                       = 400010
+	RRuleRequiresSpecifyDatetime           = 400011
+	FileTypeNotAllowed                     = 400012
+	TooManyAttachments                     = 400013
+	AttachmentNotUploaded                  = 400014
+	AttachmentImageInvalid                 = 400015
+	CursorInvalid                          = 400016
+	ParentTaskInvalid                      = 400017
+	ChecklistOrderInvalid                  = 400018
+	BlockingTaskNotFound                   = 400019
+	TaskDependencyCycle                    = 400020
+	TagNotFound                            = 400021
+	WorkflowStatusNotFound                 = 400022
+	WorkflowTransitionNotAllowed           = 400023
+	WorkflowStatusInUse                    = 400024
+	WorkflowStatusRequired                 = 400025
+	WorkflowOrderInvalid                   = 400026
+	LocaleNotSupported                     = 400027
+	TimezoneInvalid                        = 400028
 	TokenDoesNotExistOrExpired             = 401001
 	InvalidCredential                      = 401002
 	TokenContainsAnInvalidNumberOfSegments = 401003
 	FailedToLogout                         = 401004
 	RecordNotFound                         = 401005
+	RefreshTokenInvalid                    = 401006
+	RefreshTokenReused                     = 401007
+	TelegramWebhookSecretInvalid           = 401008
+	SystemCategoryIsReadOnly               = 403001
+	SignedURLInvalid                       = 403002
+	TaskTitleDuplicate                     = 409001
+	CategoryNameDuplicate                  = 409002
+	TagNameDuplicate                       = 409003
+	WorkflowStatusNameDuplicate            = 409004
 	TooManyRequests                        = 429001
 
 	// 5xx
@@ -25,26 +57,146 @@ const (
 )
 
 var (
-	messages = map[int]string{
-		// 4xx
-		400001: "No token found.",
-		400002: "Bearer token not in proper format.",
-		400003: "Token invalid.",
-		400004: "Email already exists.",
-		400005: "Email not exists.",
-		400006: "Failed to get state token.",
-		400007: "ID Invalid.",
-		400008: "Image file name limit of 100",
-		400009: "Image file size limit of 5 MB",
-		401001: "Token does not exist or expired.",
-		401002: "Invalid credential.",
-		401003: "Token contains an invalid number of segments.",
-		401004: "Failed to logout.",
-		401005: "Record not found.",
-		429001: "Too many requests.",
-
-		// 5xx
-		500001: "Signature failed.",
+	// messages are the catalogs of the error codes by locale
+	messages = map[string]map[int]string{
+		i18n.English: {
+			// 4xx
+			400001: "No token found.",
+			400002: "Bearer token not in proper format.",
+			400003: "Token invalid.",
+			400004: "Email already exists.",
+			400005: "Email not exists.",
+			400006: "Failed to get state token.",
+			400007: "ID Invalid.",
+			400008: "File name is too long.",
+			400009: "File size exceeds the limit.",
+			400010: "Category not found.",
+			400011: "Repeat rule (rrule) requires specify_datetime or specify_date.",
+			400012: "File type is not allowed.",
+			400013: "Too many attachments on the task.",
+			400014: "Attachment file has not been uploaded.",
+			400015: "Attachment image could not be processed.",
+			400016: "Cursor is invalid or does not match the sort.",
+			400017: "Parent task not found, or it is the task itself or one of its subtasks.",
+			400018: "Checklist order must contain every item of the task once.",
+			400019: "Blocking task not found.",
+			400020: "Task dependency would create a cycle.",
+			400021: "Tag not found.",
+			400022: "Workflow status not found.",
+			400023: "Moving the task to the status is not allowed.",
+			400024: "Workflow status still has tasks.",
+			400025: "Workflow must keep a status not done and a done status.",
+			400026: "Status order must contain every status of the user once.",
+			400027: "Locale is not supported.",
+			400028: "Timezone is not a valid IANA timezone.",
+			401001: "Token does not exist or expired.",
+			401002: "Invalid credential.",
+			401003: "Token contains an invalid number of segments.",
+			401004: "Failed to logout.",
+			401005: "Record not found.",
+			401006: "Refresh token does not exist or expired.",
+			401007: "Refresh token reuse detected, the session has been revoked.",
+			401008: "Telegram webhook secret token invalid.",
+			403001: "System category is read-only.",
+			403002: "Signed URL is invalid or expired.",
+			409001: "Task title already exists.",
+			409002: "Category name already exists.",
+			409003: "Tag name already exists.",
+			409004: "Workflow status name already exists.",
+			429001: "Too many requests.",
+
+			// 5xx
+			500001: "Signature failed.",
+		},
+		i18n.TraditionalChinese: {
+			// 4xx
+			400001: "找不到 Token。",
+			400002: "Bearer Token 格式不正確。",
+			400003: "Token 無效。",
+			400004: "Email 已被註冊。",
+			400005: "Email 不存在。",
+			400006: "無法取得 state token。",
+			400007: "ID 無效。",
+			400008: "檔案名稱過長。",
+			400009: "檔案大小超過限制。",
+			400010: "找不到分類。",
+			400011: "重複規則 (rrule) 需要設定 specify_datetime 或 specify_date。",
+			400012: "不允許的檔案類型。",
+			400013: "任務的附件數量過多。",
+			400014: "附件檔案尚未上傳。",
+			400015: "無法處理附件圖片。",
+			400016: "游標 (cursor) 無效或與排序不符。",
+			400017: "找不到上層任務，或為任務本身或其子任務。",
+			400018: "檢查清單的排序必須包含任務的每個項目各一次。",
+			400019: "找不到阻擋的任務。",
+			400020: "任務相依關係會形成循環。",
+			400021: "找不到標籤。",
+			400022: "找不到看板狀態。",
+			400023: "不允許將任務移至此狀態。",
+			400024: "看板狀態中仍有任務。",
+			400025: "看板必須保留一個未完成狀態及一個完成狀態。",
+			400026: "狀態的排序必須包含使用者的每個狀態各一次。",
+			400027: "不支援的語系。",
+			400028: "時區不是有效的 IANA 時區。",
+			401001: "Token 不存在或已過期。",
+			401002: "帳號或密碼錯誤。",
+			401003: "Token 的區段數量無效。",
+			401004: "登出失敗。",
+			401005: "找不到資料。",
+			401006: "Refresh token 不存在或已過期。",
+			401007: "偵測到 Refresh token 重複使用，已撤銷此登入階段。",
+			401008: "Telegram webhook 密鑰無效。",
+			403001: "系統分類為唯讀。",
+			403002: "簽章網址無效或已過期。",
+			409001: "任務標題已存在。",
+			409002: "分類名稱已存在。",
+			409003: "標籤名稱已存在。",
+			409004: "看板狀態名稱已存在。",
+			429001: "請求次數過多。",
+
+			// 5xx
+			500001: "簽章失敗。",
+		},
+	}
+
+	// texts are the translations of the response messages, by the English message
+	texts = map[string]map[string]string{
+		i18n.TraditionalChinese: {
+			"Failed to process request":              "無法處理請求",
+			"Failed to GoogleCallBack":               "Google 登入失敗",
+			"Failed to get IP rate limiter":          "超過 IP 請求次數限制",
+			"Token is not valid":                     "Token 無效",
+			"Create Success":                         "新增成功",
+			"Update Success":                         "更新成功",
+			"Update processed":                       "更新已處理",
+			"Delete Success":                         "刪除成功",
+			"Purge Success":                          "永久刪除成功",
+			"Restore Success":                        "還原成功",
+			"Upload Success":                         "上傳成功",
+			"Unlink Success":                         "解除連結成功",
+			"Login successfully":                     "登入成功",
+			"Register Success":                       "註冊成功",
+			"Refresh token successfully":             "更新 Token 成功",
+			"Google access success":                  "Google 登入成功",
+			"Successfully logged out":                "登出成功",
+			"Successfully get sessions":              "成功取得登入階段",
+			"Successfully revoked session":           "成功撤銷登入階段",
+			"Successfully revoked other sessions":    "成功撤銷其他登入階段",
+			"Successfully get profile":               "成功取得個人資料",
+			"Successfully get category":              "成功取得分類",
+			"Successfully get category list":         "成功取得分類列表",
+			"Successfully get tag":                   "成功取得標籤",
+			"Successfully get tag list":              "成功取得標籤列表",
+			"Successfully get task":                  "成功取得任務",
+			"Successfully get task list":             "成功取得任務列表",
+			"Successfully get task attachment list":  "成功取得任務附件列表",
+			"Successfully get task checklist":        "成功取得任務檢查清單",
+			"Successfully get task occurrence list":  "成功取得任務完成紀錄",
+			"Successfully get telegram link status":  "成功取得 Telegram 連結狀態",
+			"Successfully get trashed category list": "成功取得垃圾桶分類列表",
+			"Successfully get trashed task list":     "成功取得垃圾桶任務列表",
+			"Successfully get workflow status list":  "成功取得看板狀態列表",
+		},
 	}
 )
 
@@ -68,24 +220,51 @@ type PageResponse struct {
 	Data        interface{} `json:"data"`
 }
 
+// Create a new struct for the cursor (keyset) page response data
+type CursorPageResponse struct {
+	Code       int         `json:"code"`
+	Message    string      `json:"message"`
+	PageLimit  int64       `json:"pageLimit"`
+	Total      *int64      `json:"total,omitempty"` // Data count, only with with_total
+	NextCursor string      `json:"next_cursor"`     // Empty on the last page
+	PrevCursor string      `json:"prev_cursor"`     // Empty on the first page
+	Errors     interface{} `json:"errors"`
+	Data       interface{} `json:"data"`
+}
+
+// Message returns the message of the error code in the locale, falling back to the default locale and English
+func Message(locale string, errCode int) string {
+	message, _ := i18n.Translate(messages, locale, errCode)
+	return message
+}
+
+// Text returns the response message in the locale, the message itself when it has no translation
+func Text(locale string, message string) string {
+	if text, ok := i18n.Translate(texts, locale, message); ok {
+		return text
+	}
+
+	return message
+}
+
 // EmptyObj object is used when data doesnt want to be null on json
 // type EmptyObject struct {
 // }
 
 // SuccessResponse returns a success response with the given data
-func SuccessResponse(code int, message string, data interface{}) Response {
+func SuccessResponse(locale string, code int, message string, data interface{}) Response {
 	return Response{
 		Code:    code,
-		Message: message,
+		Message: Text(locale, message),
 		Errors:  nil,
 		Data:    data,
 	}
 }
 
-func SuccessPageResponse(code int, message string, currentPage int64, pageLimit int64, total int64, pages int64, data interface{}) PageResponse {
+func SuccessPageResponse(locale string, code int, message string, currentPage int64, pageLimit int64, total int64, pages int64, data interface{}) PageResponse {
 	return PageResponse{
 		Code:        code,
-		Message:     message,
+		Message:     Text(locale, message),
 		CurrentPage: currentPage,
 		PageLimit:   pageLimit,
 		Total:       total,
@@ -95,23 +274,46 @@ func SuccessPageResponse(code int, message string, currentPage int64, pageLimit
 	}
 }
 
+func SuccessCursorPageResponse(locale string, code int, message string, pageLimit int64, total *int64, nextCursor string, prevCursor string, data interface{}) CursorPageResponse {
+	return CursorPageResponse{
+		Code:       code,
+		Message:    Text(locale, message),
+		PageLimit:  pageLimit,
+		Total:      total,
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+		Errors:     nil,
+		Data:       data,
+	}
+}
+
 // ErrorResponse returns an error response with the given data
-func ErrorsResponse(code int, message string, err string, data interface{}) Response {
+func ErrorsResponse(locale string, code int, message string, err string, data interface{}) Response {
 	splittedError := strings.Split(err, "\n")
 	return Response{
 		Code:    code,
-		Message: message,
+		Message: Text(locale, message),
 		Errors:  splittedError,
 		Data:    data,
 	}
 }
 
+// FieldErrorsResponse returns an error response with the failed validation rules of the request fields
+func FieldErrorsResponse(locale string, code int, message string, fields []validation.FieldError, data interface{}) Response {
+	return Response{
+		Code:    code,
+		Message: Text(locale, message),
+		Errors:  fields,
+		Data:    data,
+	}
+}
+
 // ErrorResponse Returns an error response with the message given by the code
-func ErrorsResponseByCode(code int, message string, errCode int, data interface{}) Response {
+func ErrorsResponseByCode(locale string, code int, message string, errCode int, data interface{}) Response {
 	return Response{
 		Code:    code,
-		Message: message,
-		Errors:  messages[errCode],
+		Message: Text(locale, message),
+		Errors:  Message(locale, errCode),
 		Data:    data,
 	}
 }
@@ -0,0 +1,357 @@
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported frequencies (RFC 5545 FREQ)
+const (
+	Daily   = "DAILY"
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+	Yearly  = "YEARLY"
+)
+
+// Stop searching for the next occurrence after this many periods
+const maxIterations = 1000
+
+// Layouts of UNTIL: a UTC date-time, a floating date-time (in the timezone of the occurrences) and a date
+const (
+	untilUTCLayout      = "20060102T150405Z"
+	untilFloatingLayout = "20060102T150405"
+	untilDateLayout     = "20060102"
+)
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// WeekdayNum is a BYDAY value, N is the ordinal inside the month (0 means every, 1 first, -1 last)
+type WeekdayNum struct {
+	N       int
+	Weekday time.Weekday
+}
+
+// RRule is the subset of RFC 5545 recurrence rules used by tasks
+// The rule is evaluated from the current occurrence, so the DTSTART is always the current occurrence
+type RRule struct {
+	Freq     string
+	Interval int
+	ByDay    []WeekdayNum
+	// The time in UTC, or the wall clock of a floating date-time or a date (see UntilIn)
+	Until *time.Time
+	Count int
+	// Layout UNTIL was given in
+	untilLayout string
+}
+
+// Parse parses a rule like "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10" (the "RRULE:" prefix is optional)
+func Parse(rule string) (*RRule, error) {
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+	if rule == "" {
+		return nil, errors.New("rrule is empty")
+	}
+
+	r := &RRule{Interval: 1}
+	for _, part := range strings.Split(rule, ";") {
+		keyValue := strings.SplitN(part, "=", 2)
+		if len(keyValue) != 2 || keyValue[1] == "" {
+			return nil, fmt.Errorf("rrule part %q is not in KEY=VALUE format", part)
+		}
+
+		key, value := keyValue[0], keyValue[1]
+		switch key {
+		case "FREQ":
+			switch value {
+			case Daily, Weekly, Monthly, Yearly:
+				r.Freq = value
+			default:
+				return nil, fmt.Errorf("rrule FREQ %q is not supported", value)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("rrule INTERVAL %q must be a positive integer", value)
+			}
+			r.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("rrule COUNT %q must be a positive integer", value)
+			}
+			r.Count = count
+		case "UNTIL":
+			until, layout, err := parseUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			r.Until, r.untilLayout = &until, layout
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekdayNum, err := parseWeekdayNum(day)
+				if err != nil {
+					return nil, err
+				}
+				r.ByDay = append(r.ByDay, weekdayNum)
+			}
+		case "WKST":
+			if value != "MO" {
+				return nil, errors.New("rrule WKST only supports MO")
+			}
+		default:
+			return nil, fmt.Errorf("rrule %s is not supported", key)
+		}
+	}
+
+	if r.Freq == "" {
+		return nil, errors.New("rrule FREQ is required")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return nil, errors.New("rrule UNTIL and COUNT must not occur in the same rule")
+	}
+	if r.Freq == Yearly && len(r.ByDay) > 0 {
+		return nil, errors.New("rrule BYDAY is not supported with FREQ=YEARLY")
+	}
+	for _, day := range r.ByDay {
+		if day.N != 0 && r.Freq != Monthly {
+			return nil, errors.New("rrule BYDAY with an ordinal is only supported with FREQ=MONTHLY")
+		}
+	}
+
+	return r, nil
+}
+
+// parseUntil accepts a UTC date-time, a floating date-time or a date, the floating ones are kept as a wall clock in UTC
+func parseUntil(value string) (time.Time, string, error) {
+	for _, layout := range []string{untilUTCLayout, untilFloatingLayout, untilDateLayout} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, layout, nil
+		}
+	}
+
+	return time.Time{}, "", fmt.Errorf("rrule UNTIL %q is not a valid date or date-time", value)
+}
+
+// UntilIn returns UNTIL for the occurrences in the location, a floating date-time is the wall clock of the location
+// and a date is inclusive until the end of the day
+func (r *RRule) UntilIn(loc *time.Location) time.Time {
+	u := *r.Until
+	switch r.untilLayout {
+	case untilFloatingLayout:
+		return time.Date(u.Year(), u.Month(), u.Day(), u.Hour(), u.Minute(), u.Second(), 0, loc)
+	case untilDateLayout:
+		return time.Date(u.Year(), u.Month(), u.Day()+1, 0, 0, 0, 0, loc).Add(-time.Second)
+	}
+
+	return u
+}
+
+// parseWeekdayNum parses a BYDAY value like "MO", "1MO" or "-1FR"
+func parseWeekdayNum(value string) (WeekdayNum, error) {
+	if len(value) < 2 {
+		return WeekdayNum{}, fmt.Errorf("rrule BYDAY %q is not valid", value)
+	}
+
+	weekday, ok := weekdays[value[len(value)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("rrule BYDAY %q is not valid", value)
+	}
+
+	n := 0
+	if ordinal := value[:len(value)-2]; ordinal != "" {
+		var err error
+		n, err = strconv.Atoi(ordinal)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return WeekdayNum{}, fmt.Errorf("rrule BYDAY %q is not valid", value)
+		}
+	}
+
+	return WeekdayNum{N: n, Weekday: weekday}, nil
+}
+
+// String returns the normalized rule
+func (r *RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			name := ""
+			for k, v := range weekdays {
+				if v == day.Weekday {
+					name = k
+				}
+			}
+			if day.N != 0 {
+				name = strconv.Itoa(day.N) + name
+			}
+			days = append(days, name)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if r.Until != nil {
+		layout := r.untilLayout
+		if layout == "" {
+			layout = untilUTCLayout
+		}
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(layout))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Next returns the occurrence following current, occurrence is the 1-based index of current in the series
+// The second return value is false once the series has ended (COUNT or UNTIL reached), a floating UNTIL is in the location of current
+func (r *RRule) Next(current time.Time, occurrence int) (time.Time, bool) {
+	if r.Count > 0 && occurrence >= r.Count {
+		return time.Time{}, false
+	}
+
+	var next time.Time
+	var ok bool
+	switch r.Freq {
+	case Daily:
+		next, ok = r.nextDaily(current)
+	case Weekly:
+		next, ok = r.nextWeekly(current)
+	case Monthly:
+		next, ok = r.nextMonthly(current)
+	case Yearly:
+		next, ok = r.nextYearly(current)
+	}
+
+	if !ok || (r.Until != nil && next.After(r.UntilIn(current.Location()))) {
+		return time.Time{}, false
+	}
+
+	return next, true
+}
+
+func (r *RRule) nextDaily(current time.Time) (time.Time, bool) {
+	for i := 1; i <= maxIterations; i++ {
+		t := current.AddDate(0, 0, i*r.Interval)
+		if r.matchWeekday(t) {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (r *RRule) nextWeekly(current time.Time) (time.Time, bool) {
+	if len(r.ByDay) == 0 {
+		return current.AddDate(0, 0, 7*r.Interval), true
+	}
+
+	// Remaining days of the current week (weeks start on Monday)
+	offset := (int(current.Weekday()) + 6) % 7
+	for d := 1; offset+d < 7; d++ {
+		t := current.AddDate(0, 0, d)
+		if r.matchWeekday(t) {
+			return t, true
+		}
+	}
+
+	weekStart := current.AddDate(0, 0, 7*r.Interval-offset)
+	for d := 0; d < 7; d++ {
+		t := weekStart.AddDate(0, 0, d)
+		if r.matchWeekday(t) {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (r *RRule) nextMonthly(current time.Time) (time.Time, bool) {
+	for k := 0; k <= maxIterations; k++ {
+		first := time.Date(current.Year(), current.Month()+time.Month(k*r.Interval), 1, current.Hour(), current.Minute(), current.Second(), current.Nanosecond(), current.Location())
+
+		// Without BYDAY keep the day of month, months without that day are skipped
+		days := []int{current.Day()}
+		if len(r.ByDay) > 0 {
+			days = r.monthDays(first)
+		}
+
+		for _, day := range days {
+			if day > daysIn(first) {
+				continue
+			}
+			t := first.AddDate(0, 0, day-1)
+			if t.After(current) {
+				return t, true
+			}
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (r *RRule) nextYearly(current time.Time) (time.Time, bool) {
+	for k := 1; k <= maxIterations; k++ {
+		first := time.Date(current.Year()+k*r.Interval, current.Month(), 1, current.Hour(), current.Minute(), current.Second(), current.Nanosecond(), current.Location())
+		// February 29th only occurs in leap years
+		if current.Day() <= daysIn(first) {
+			return first.AddDate(0, 0, current.Day()-1), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// matchWeekday reports whether t matches one of the BYDAY weekdays (always true without BYDAY)
+func (r *RRule) matchWeekday(t time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+
+	for _, day := range r.ByDay {
+		if day.Weekday == t.Weekday() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// monthDays returns the days of the month matching BYDAY in ascending order
+func (r *RRule) monthDays(first time.Time) []int {
+	var days []int
+	n := daysIn(first)
+	for day := 1; day <= n; day++ {
+		weekday := first.AddDate(0, 0, day-1).Weekday()
+		for _, byDay := range r.ByDay {
+			if byDay.Weekday != weekday {
+				continue
+			}
+			if byDay.N == 0 || byDay.N == (day-1)/7+1 || byDay.N == -((n-day)/7+1) {
+				days = append(days, day)
+				break
+			}
+		}
+	}
+
+	return days
+}
+
+// daysIn returns the number of days of the month of t
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+}
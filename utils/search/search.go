@@ -0,0 +1,154 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// Markers around the matched terms in the snippets
+const (
+	MarkOpen  = "<mark>"
+	MarkClose = "</mark>"
+)
+
+// Length of the snippets (in characters, without the markers)
+const snippetLength = 160
+
+// Maximum number of terms of a query, the rest is ignored
+const maxTerms = 10
+
+// Terms splits the query into its words, the operators of the MySQL boolean mode are removed
+func Terms(q string) []string {
+	clean := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`+-<>()~*"@`, r) {
+			return ' '
+		}
+		return r
+	}, q)
+
+	terms := []string{}
+	seen := map[string]bool{}
+	for _, term := range strings.Fields(clean) {
+		key := strings.ToLower(term)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		terms = append(terms, term)
+		if len(terms) == maxTerms {
+			break
+		}
+	}
+
+	return terms
+}
+
+// BooleanQuery returns the query of MATCH ... AGAINST (... IN BOOLEAN MODE) requiring every term,
+// each term is a phrase so that it is matched as a whole by the ngram parser
+func BooleanQuery(terms []string) string {
+	quoted := make([]string, len(terms))
+	for i, term := range terms {
+		quoted[i] = `+"` + term + `"`
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+// LikePattern returns the pattern of LIKE matching the term anywhere, its wildcards are escaped with "!"
+// (LIKE ? ESCAPE '!', the backslash is not the same in every database)
+func LikePattern(term string) string {
+	return "%" + strings.NewReplacer(`!`, `!!`, `%`, `!%`, `_`, `!_`).Replace(term) + "%"
+}
+
+// Highlight returns a snippet of the text around the first matched term, with the terms wrapped in the markers.
+// The text is HTML escaped, so the snippet can be rendered as is. Empty if no term matches
+func Highlight(text string, terms []string) string {
+	runes := []rune(text)
+	lower := lowerRunes(runes)
+
+	matched := make([]bool, len(runes))
+	first := -1
+	for _, term := range terms {
+		needle := lowerRunes([]rune(term))
+		if len(needle) == 0 {
+			continue
+		}
+		for i := 0; i+len(needle) <= len(lower); i++ {
+			if !hasPrefix(lower[i:], needle) {
+				continue
+			}
+			for j := i; j < i+len(needle); j++ {
+				matched[j] = true
+			}
+			if first == -1 || i < first {
+				first = i
+			}
+		}
+	}
+	if first == -1 {
+		return ""
+	}
+
+	// Keep a bit of context before the first match
+	start := first - snippetLength/4
+	if start < 0 {
+		start = 0
+	}
+	end := start + snippetLength
+	if end > len(runes) {
+		end = len(runes)
+		start = end - snippetLength
+		if start < 0 {
+			start = 0
+		}
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	inMark := false
+	for i := start; i < end; i++ {
+		if matched[i] && !inMark {
+			b.WriteString(MarkOpen)
+			inMark = true
+		}
+		if !matched[i] && inMark {
+			b.WriteString(MarkClose)
+			inMark = false
+		}
+		b.WriteString(html.EscapeString(string(runes[i])))
+	}
+	if inMark {
+		b.WriteString(MarkClose)
+	}
+	if end < len(runes) {
+		b.WriteString("…")
+	}
+
+	return b.String()
+}
+
+// lowerRunes lower-cases rune by rune, so that the indexes stay the same as the original
+func lowerRunes(runes []rune) []rune {
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	return lower
+}
+
+func hasPrefix(s []rune, prefix []rune) bool {
+	if len(s) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if s[i] != prefix[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,270 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go-todolist/utils/apperr"
+	"go-todolist/utils/log"
+	"go-todolist/utils/responses"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Storage drivers (STORAGE_DRIVER)
+const (
+	// AWS S3
+	DriverS3 = "s3"
+	// Any S3-compatible endpoint, e.g. MinIO
+	DriverMinio = "minio"
+	// Local filesystem, served through the signed download route
+	DriverLocal = "local"
+)
+
+// Config is the object storage configuration, loaded once from .env
+type Config struct {
+	Driver string
+	Bucket string
+
+	// S3 and S3-compatible drivers
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+	Endpoint        string
+	UsePathStyle    bool
+
+	// Local driver
+	LocalPath  string
+	LocalURL   string
+	SigningKey string
+
+	// TTL of the download links
+	URLTTL time.Duration
+	// TTL of the upload links
+	UploadURLTTL time.Duration
+
+	Limits Limits
+	// Sizes (px) of the thumbnails of the images
+	ThumbnailSizes []int
+}
+
+// Limits are the per-file limits of uploaded files
+type Limits struct {
+	MaxFileSize       int64
+	MaxFilenameLength int
+	// Maximum number of attachments per task
+	MaxFiles int
+	// Allowed content types, "image/*" matches every image type
+	AllowedTypes []string
+}
+
+var (
+	ErrFileNameTooLong    = apperr.New(apperr.Validation, responses.FileNameTooLong)
+	ErrFileTooLarge       = apperr.New(apperr.Validation, responses.FileTooLarge)
+	ErrFileTypeNotAllowed = apperr.New(apperr.Validation, responses.FileTypeNotAllowed)
+	ErrSignedURLInvalid   = apperr.New(apperr.Forbidden, responses.SignedURLInvalid)
+)
+
+func InitStorage() Config {
+	errEnv := godotenv.Load()
+	if errEnv != nil {
+		log.Panic("Failed to load env file")
+	}
+
+	config := Config{
+		Driver:          strings.ToLower(os.Getenv("STORAGE_DRIVER")),
+		Bucket:          os.Getenv("AWS_BUCKET"),
+		Region:          os.Getenv("AWS_REGION"),
+		AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		Endpoint:        os.Getenv("AWS_ENDPOINT"),
+		LocalPath:       os.Getenv("STORAGE_LOCAL_PATH"),
+		LocalURL:        strings.TrimRight(os.Getenv("STORAGE_LOCAL_URL"), "/"),
+		SigningKey:      os.Getenv("STORAGE_SIGNING_KEY"),
+	}
+	config.UsePathStyle, _ = strconv.ParseBool(os.Getenv("AWS_USE_PATH_STYLE"))
+
+	// If the environment variable is empty, use a default value
+	if config.Driver == "" {
+		config.Driver = DriverS3
+	}
+	if config.LocalPath == "" {
+		config.LocalPath = "./storage"
+	}
+	if config.LocalURL == "" {
+		config.LocalURL = fmt.Sprintf("http://%s:%s/api/v1/storage", os.Getenv("APP_HOST"), os.Getenv("SERVER_PORT"))
+	}
+	// The links of the local storage are signed with their own key, it is required
+	if config.Driver == DriverLocal && config.SigningKey == "" {
+		log.Panic("STORAGE_SIGNING_KEY is required by the local storage")
+	}
+
+	// The links are signed again on every response, so they can be short-lived
+	// (an S3 pre-signed URL is valid for 7 days at most)
+	ttl, _ := strconv.Atoi(os.Getenv("STORAGE_URL_TTL"))
+	if ttl < 1 {
+		ttl = 3600
+	}
+	config.URLTTL = time.Duration(ttl) * time.Second
+
+	uploadTTL, _ := strconv.Atoi(os.Getenv("STORAGE_UPLOAD_URL_TTL"))
+	if uploadTTL < 1 {
+		uploadTTL = 900
+	}
+	config.UploadURLTTL = time.Duration(uploadTTL) * time.Second
+
+	config.Limits = Limits{
+		MaxFileSize:       5 << 20,
+		MaxFilenameLength: 100,
+		MaxFiles:          10,
+		AllowedTypes:      []string{"image/*", "application/pdf", "text/plain"},
+	}
+	if size, _ := strconv.ParseInt(os.Getenv("UPLOAD_MAX_FILE_SIZE"), 10, 64); size > 0 {
+		config.Limits.MaxFileSize = size
+	}
+	if length, _ := strconv.Atoi(os.Getenv("UPLOAD_MAX_FILENAME_LENGTH")); length > 0 {
+		config.Limits.MaxFilenameLength = length
+	}
+	if files, _ := strconv.Atoi(os.Getenv("UPLOAD_MAX_FILES")); files > 0 {
+		config.Limits.MaxFiles = files
+	}
+	if types := os.Getenv("UPLOAD_ALLOWED_TYPES"); types != "" {
+		config.Limits.AllowedTypes = strings.Split(strings.ReplaceAll(types, " ", ""), ",")
+	}
+
+	config.ThumbnailSizes = []int{128, 512}
+	if sizes := os.Getenv("IMAGE_THUMBNAIL_SIZES"); sizes != "" {
+		config.ThumbnailSizes = []int{}
+		for _, size := range strings.Split(sizes, ",") {
+			if px, _ := strconv.Atoi(strings.TrimSpace(size)); px > 0 {
+				config.ThumbnailSizes = append(config.ThumbnailSizes, px)
+			}
+		}
+	}
+
+	return config
+}
+
+// DetectContentType returns the content type of the file by its magic bytes, the name and the declared type are not trusted
+func DetectContentType(data []byte) string {
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(data))
+	if err != nil {
+		return "application/octet-stream"
+	}
+
+	return mediaType
+}
+
+// ContentType returns the content type of the uploaded file by its magic bytes
+func ContentType(file *multipart.FileHeader) (string, error) {
+	f, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	// http.DetectContentType considers at most the first 512 bytes
+	head := make([]byte, 512)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
+	}
+
+	return DetectContentType(head[:n]), nil
+}
+
+// Check validates the uploaded file against the limits
+func (l Limits) Check(file *multipart.FileHeader) error {
+	contentType, err := ContentType(file)
+	if err != nil {
+		return err
+	}
+
+	return l.CheckFile(file.Filename, file.Size, contentType)
+}
+
+// CheckFile validates the name, size and content type of a file against the limits
+func (l Limits) CheckFile(filename string, size int64, contentType string) error {
+	if len(filename) > l.MaxFilenameLength {
+		return ErrFileNameTooLong
+	}
+	if size > l.MaxFileSize {
+		return ErrFileTooLarge
+	}
+
+	for _, allowed := range l.AllowedTypes {
+		if allowed == contentType || (strings.HasSuffix(allowed, "/*") && strings.HasPrefix(contentType, strings.TrimSuffix(allowed, "*"))) {
+			return nil
+		}
+	}
+
+	return ErrFileTypeNotAllowed
+}
+
+// Sign returns the HMAC signature of the HTTP method, the object key and its expiry (unix time)
+func (c Config) Sign(method string, key string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(c.SigningKey))
+	mac.Write([]byte(method + "\n" + key + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature and the expiry of a signed URL
+func (c Config) Verify(method string, key string, expires int64, signature string) bool {
+	if time.Now().Unix() > expires {
+		return false
+	}
+
+	return hmac.Equal([]byte(c.Sign(method, key, expires)), []byte(signature))
+}
+
+// SignedURL returns a download URL of the object key valid for ttl
+func (c Config) SignedURL(key string, ttl time.Duration) string {
+	return c.signedURL(http.MethodGet, key, ttl)
+}
+
+// SignedUploadURL returns an upload (PUT) URL of the object key valid for ttl
+func (c Config) SignedUploadURL(key string, ttl time.Duration) string {
+	return c.signedURL(http.MethodPut, key, ttl)
+}
+
+func (c Config) signedURL(method string, key string, ttl time.Duration) string {
+	expires := time.Now().Add(ttl).Unix()
+
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", c.Sign(method, key, expires))
+
+	return c.LocalURL + "/" + strings.Join(segments, "/") + "?" + query.Encode()
+}
+
+// LocalFile returns the path of the object key under LocalPath, keys escaping LocalPath are rejected
+func (c Config) LocalFile(key string) (string, error) {
+	root, err := filepath.Abs(c.LocalPath)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(root, filepath.FromSlash(key))
+	if !strings.HasPrefix(path, root+string(filepath.Separator)) {
+		return "", errors.New("Invalid object key.")
+	}
+
+	return path, nil
+}
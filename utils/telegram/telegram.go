@@ -0,0 +1,42 @@
+package telegram
+
+import (
+	"go-todolist/utils/log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Client holds the Telegram Bot API settings
+type Client struct {
+	// Bot API base URL, can point to a local stub server for testing
+	BaseURL string
+	Token   string
+	HTTP    *http.Client
+}
+
+func InitTelegram() *Client {
+	errEnv := godotenv.Load()
+	if errEnv != nil {
+		log.Panic("Failed to load env file")
+	}
+
+	baseURL := os.Getenv("TELEGRAM_API_URL")
+	if baseURL == "" {
+		baseURL = "https://api.telegram.org"
+	}
+
+	token := os.Getenv("TELEGRAM_BOT_TOKEN")
+	if token == "" {
+		log.Warn("InitTelegram : TELEGRAM_BOT_TOKEN is empty, telegram notifications are disabled")
+	}
+
+	return &Client{
+		BaseURL: strings.TrimRight(baseURL, "/"),
+		Token:   token,
+		HTTP:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
@@ -0,0 +1,180 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"go-todolist/utils/civilDatetime"
+	"go-todolist/utils/i18n"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError is a failed validation rule of a request field
+type FieldError struct {
+	Field   string `json:"field"`   // Name of the field in the request (form, json or uri)
+	Rule    string `json:"rule"`    // Validation tag, e.g. required, max
+	Param   string `json:"param"`   // Parameter of the rule, e.g. 100 for max=100
+	Message string `json:"message"` // Readable message
+}
+
+// Messages of the rules by locale, the ones depending on the type of the field are suffixed with
+// .string, .number or .list. {field} and {param} are replaced with those of the error
+var messages = map[string]map[string]string{
+	i18n.English: {
+		"required":    "{field} is required.",
+		"email":       "{field} must be a valid email address.",
+		"hexcolor":    "{field} must be a hexadecimal color, e.g. #ff0000.",
+		"uuid4":       "{field} must be a UUID v4.",
+		"oneof":       "{field} must be one of: {param}.",
+		"len.string":  "{field} must be {param} characters long.",
+		"len.number":  "{field} must be {param}.",
+		"len.list":    "{field} must contain {param} items.",
+		"min.string":  "{field} must be at least {param} characters long.",
+		"min.number":  "{field} must be {param} or greater.",
+		"min.list":    "{field} must contain at least {param} items.",
+		"max.string":  "{field} must be at most {param} characters long.",
+		"max.number":  "{field} must be {param} or less.",
+		"max.list":    "{field} must contain at most {param} items.",
+		"gt.number":   "{field} must be greater than {param}.",
+		"gt.list":     "{field} must contain more than {param} items.",
+		"gte.number":  "{field} must be {param} or greater.",
+		"gte.list":    "{field} must contain at least {param} items.",
+		"lt.number":   "{field} must be less than {param}.",
+		"lt.list":     "{field} must contain less than {param} items.",
+		"lte.number":  "{field} must be {param} or less.",
+		"lte.list":    "{field} must contain at most {param} items.",
+		"type":        "{field} must be a {param}.",
+		"datetime":    "{field} must match the format {param}.",
+		"datetime_tz": "{field} must be an RFC 3339 datetime (2006-01-02T15:04:05+08:00) or 2006-01-02 15:04:05.",
+		"invalid":     "{field} is invalid.",
+	},
+	i18n.TraditionalChinese: {
+		"required":    "{field} 為必填。",
+		"email":       "{field} 必須是有效的 Email。",
+		"hexcolor":    "{field} 必須是十六進位色碼，例如 #ff0000。",
+		"uuid4":       "{field} 必須是 UUID v4。",
+		"oneof":       "{field} 必須是下列其中之一：{param}。",
+		"len.string":  "{field} 長度必須為 {param} 個字元。",
+		"len.number":  "{field} 必須為 {param}。",
+		"len.list":    "{field} 必須包含 {param} 個項目。",
+		"min.string":  "{field} 長度至少為 {param} 個字元。",
+		"min.number":  "{field} 必須大於或等於 {param}。",
+		"min.list":    "{field} 至少須包含 {param} 個項目。",
+		"max.string":  "{field} 長度最多為 {param} 個字元。",
+		"max.number":  "{field} 必須小於或等於 {param}。",
+		"max.list":    "{field} 最多只能包含 {param} 個項目。",
+		"gt.number":   "{field} 必須大於 {param}。",
+		"gt.list":     "{field} 必須包含超過 {param} 個項目。",
+		"gte.number":  "{field} 必須大於或等於 {param}。",
+		"gte.list":    "{field} 至少須包含 {param} 個項目。",
+		"lt.number":   "{field} 必須小於 {param}。",
+		"lt.list":     "{field} 必須包含少於 {param} 個項目。",
+		"lte.number":  "{field} 必須小於或等於 {param}。",
+		"lte.list":    "{field} 最多只能包含 {param} 個項目。",
+		"type":        "{field} 必須是 {param}。",
+		"datetime":    "{field} 的格式必須為 {param}。",
+		"datetime_tz": "{field} 必須是 RFC 3339 日期時間 (2006-01-02T15:04:05+08:00) 或 2006-01-02 15:04:05。",
+		"invalid":     "{field} 無效。",
+	},
+}
+
+// Init names the fields of the validation errors after their form, json or uri tag instead of the Go field,
+// and registers the rules of the repo (datetime_tz)
+func Init() {
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(fieldName)
+		v.RegisterValidation("datetime_tz", isDatetime)
+	}
+}
+
+// isDatetime checks an RFC 3339 datetime or one without a zone, see civilDatetime.ParseDatetime
+func isDatetime(fl validator.FieldLevel) bool {
+	_, err := civilDatetime.ParseDatetime(fl.Field().String(), time.UTC)
+	return err == nil
+}
+
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"form", "json", "uri"} {
+		name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+// FromError lists the errors of the request fields in the error of a binding with the messages in the locale,
+// false if it is not about the fields
+func FromError(locale string, err error) ([]FieldError, bool) {
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return Fields(locale, validationErrs), true
+	}
+
+	// A JSON value of the wrong type
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return []FieldError{{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Param:   typeErr.Type.Kind().String(),
+			Message: format(template(locale, "type"), typeErr.Field, typeErr.Type.Kind().String()),
+		}}, true
+	}
+
+	return nil, false
+}
+
+// Fields converts the validation errors into the errors of the request fields with the messages in the locale
+func Fields(locale string, errs validator.ValidationErrors) []FieldError {
+	fields := make([]FieldError, len(errs))
+	for i, err := range errs {
+		fields[i] = FieldError{
+			Field:   err.Field(),
+			Rule:    err.Tag(),
+			Param:   err.Param(),
+			Message: message(locale, err),
+		}
+	}
+
+	return fields
+}
+
+// message finds the message of the rule for the type of the field
+func message(locale string, err validator.FieldError) string {
+	rule := err.Tag() + "." + kindName(err.Kind())
+	if template(locale, rule) == "" {
+		rule = err.Tag()
+	}
+	if template(locale, rule) == "" {
+		rule = "invalid"
+	}
+
+	return format(template(locale, rule), err.Field(), err.Param())
+}
+
+// template finds the message of the rule in the locale, following the fallback chain of the locales
+func template(locale string, rule string) string {
+	text, _ := i18n.Translate(messages, locale, rule)
+	return text
+}
+
+func format(template string, field string, param string) string {
+	return strings.NewReplacer("{field}", field, "{param}", param).Replace(template)
+}
+
+func kindName(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "list"
+	}
+
+	return "number"
+}